@@ -17,8 +17,14 @@ func Analyzer(input string) (string, error) {
         return "", nil // Retorna vacío y sin error
     }
 
-    // Divide la entrada en tokens usando espacios en blanco como delimitadores
-    tokens := strings.Fields(input)
+    // Quitar el comentario al final de la línea (ej. mkdisk -size=5 -path=/a.mia # disco 1)
+    input, _ = SplitComment(input)
+
+    // Divide la entrada en tokens respetando las comillas y los caracteres escapados
+    tokens, err := Tokenize(input)
+    if err != nil {
+        return "", err
+    }
 
     // Si no se proporcionó ningún comando, devuelve un error
     if len(tokens) == 0 {
@@ -42,6 +48,9 @@ func Analyzer(input string) (string, error) {
     case "mount":
         return commands.ParseMount(tokens[1:])
 
+    case "unmount":
+        return commands.ParseUnmount(tokens[1:])
+
     case "mkfs":
         return commands.ParseMkfs(tokens[1:])
 
@@ -51,14 +60,38 @@ func Analyzer(input string) (string, error) {
     case "mkdir":
         return commands.ParseMkdir(tokens[1:])
 
+    case "mkfile":
+        return commands.ParseMkfile(tokens[1:])
+
+    case "cat":
+        return commands.ParseCat(tokens[1:])
+
     case "login":
         return commands.ParseLogin(tokens[1:])
 
     case "logout":
         return commands.ParseLogout(tokens[1:])
 
+    case "mkgrp":
+        return commands.ParseMkgrp(tokens[1:])
+
+    case "rmgrp":
+        return commands.ParseRmgrp(tokens[1:])
+
+    case "mkusr":
+        return commands.ParseMkusr(tokens[1:])
+
+    case "rmusr":
+        return commands.ParseRmusr(tokens[1:])
+
+    case "chgrp":
+        return commands.ParseChgrp(tokens[1:])
+
     case "mounted":
         return commands.ParseMounted(tokens[1:])
+
+    case "checkdisk":
+        return commands.ParseCheckdisk(tokens[1:])
         
     default:
         // Si el comando no es reconocido, devuelve un error
@@ -0,0 +1,82 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Estados del resultado de una línea del script
+const (
+	StatusOK      = "ok"
+	StatusError   = "error"
+	StatusComment = "comment"
+)
+
+// LineResult resultado de ejecutar una línea de un script
+type LineResult struct {
+	Line    int    `json:"line"`             // Número de línea, empezando en 1
+	Command string `json:"command"`          // Comando ejecutado, sin el comentario final
+	Status  string `json:"status"`           // ok, error o comment
+	Output  string `json:"output,omitempty"` // Salida del comando o texto del comentario
+	Error   string `json:"error,omitempty"`  // Mensaje de error si el comando falló
+}
+
+/*
+   mkdisk -size=5 -unit=M -path=/home/Disco1.mia   # Disco principal
+   # Particiones
+   fdisk -size=1 -path=/home/Disco1.mia -name=Particion1
+*/
+
+// RunScript ejecuta cada línea del script y devuelve el resultado de cada una.
+// Las líneas vacías se omiten; un error en una línea no detiene las siguientes
+func RunScript(script string) []LineResult {
+	var results []LineResult
+
+	// Aceptar saltos de línea de Windows
+	script = strings.ReplaceAll(script, "\r\n", "\n")
+
+	for i, line := range strings.Split(script, "\n") {
+		command, comment := SplitComment(line)
+		if command == "" && comment == "" {
+			continue
+		}
+
+		result := LineResult{Line: i + 1, Command: command}
+
+		switch {
+		case command == "":
+			// Línea que solo contiene un comentario
+			result.Status = StatusComment
+			result.Output = comment
+		default:
+			output, err := Analyzer(command)
+			if err != nil {
+				result.Status = StatusError
+				result.Error = err.Error()
+			} else {
+				result.Status = StatusOK
+				result.Output = output
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// FormatResults arma la salida de texto de un script a partir de los resultados de cada línea
+func FormatResults(results []LineResult) string {
+	var output strings.Builder
+
+	for _, result := range results {
+		switch result.Status {
+		case StatusError:
+			output.WriteString(fmt.Sprintf("Error en la línea %d (%s): %s\n", result.Line, result.Command, result.Error))
+		default:
+			output.WriteString(result.Output + "\n")
+		}
+	}
+
+	return output.String()
+}
\ No newline at end of file
@@ -0,0 +1,95 @@
+package analyzer
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+/*
+   mkdir -path="/home/mis documentos"     -> [mkdir, -path=/home/mis documentos]
+   mkdir -path=/home/mis\ documentos      -> [mkdir, -path=/home/mis documentos]
+   mkfile -path="/home/\"a\".txt"         -> [mkfile, -path=/home/"a".txt]
+*/
+
+// Tokenize divide una línea en tokens separados por espacios en blanco.
+// Los espacios entre comillas dobles no separan tokens y las comillas se eliminan.
+// La barra invertida escapa comillas, espacios y otra barra invertida
+func Tokenize(input string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inToken := false  // Se está construyendo un token (puede ser "" si venía entre comillas)
+	inQuotes := false // Se está dentro de comillas dobles
+	escaped := false  // El carácter anterior fue una barra invertida
+
+	for _, r := range input {
+		switch {
+		case escaped:
+			// Solo se escapan comillas, espacios y barras; en otro caso se conserva la barra
+			if r != '"' && r != '\\' && !unicode.IsSpace(r) {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+			inToken = true
+		case r == '"':
+			inQuotes = !inQuotes
+			inToken = true
+		case unicode.IsSpace(r) && !inQuotes:
+			// Fin del token actual
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+
+	if inQuotes {
+		return nil, errors.New("comillas sin cerrar en el comando")
+	}
+	if escaped {
+		// Una barra invertida al final se conserva tal cual
+		current.WriteRune('\\')
+	}
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens, nil
+}
+
+// SplitComment separa una línea en el comando y el comentario que tenga al final.
+// Un # inicia un comentario solo si está fuera de comillas, no está escapado
+// y está al inicio de la línea o después de un espacio (ej. -path=/a#b no es comentario)
+func SplitComment(line string) (string, string) {
+	inQuotes := false
+	escaped := false
+	previousSpace := true
+
+	for i, r := range line {
+		// El carácter escapado nunca inicia un comentario
+		if escaped {
+			escaped = false
+			previousSpace = false
+			continue
+		}
+
+		switch {
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == '#' && !inQuotes && previousSpace:
+			return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i:])
+		}
+		previousSpace = unicode.IsSpace(r)
+	}
+
+	return strings.TrimSpace(line), ""
+}
\ No newline at end of file
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	stores "backend/stores"
+	structures "backend/structures"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CAT estructura que representa el comando cat con sus parámetros
+type CAT struct {
+	files []string // Paths de los archivos, en el orden de -file1, -file2, ...
+}
+
+/*
+   cat -file1=/home/user/docs/a.txt
+   cat -file1=/home/a.txt -file2=/home/b.txt
+*/
+
+// Parámetros aceptados por cat
+var catSchema = Schema{
+	Command: "cat",
+	Params: []Param{
+		{Name: "file", Required: true, Numbered: true},
+	},
+}
+
+func ParseCat(tokens []string) (string, error) {
+	// Validar los parámetros contra el esquema del comando
+	params, err := catSchema.Parse(tokens)
+	if err != nil {
+		return "", err
+	}
+
+	// Los archivos quedan en el orden de -file1, -file2, ...
+	cmd := &CAT{
+		files: params.Numbered("file"),
+	}
+
+	// Ejecutar el comando cat con los parámetros proporcionados
+	return commandCat(cmd)
+}
+
+func commandCat(cat *CAT) (string, error) {
+	// Obtener el id de la partición montada que está logueada
+	if !stores.Auth.IsAuthenticated() {
+		return "", errors.New("no se ha iniciado sesión en ninguna partición")
+	}
+	username, partitionID := stores.Auth.GetCurrentUser()
+	uid, gid := stores.Auth.GetUserIDs()
+
+	// Obtener la partición montada
+	partitionSuperblock, _, partitionPath, err := stores.GetMountedPartitionSuperblock(partitionID)
+	if err != nil {
+		return "", fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+
+	var contents []string
+	for _, filePath := range cat.files {
+		content, err := readFile(filePath, partitionSuperblock, partitionPath, username == "root", uid, gid)
+		if err != nil {
+			return "", fmt.Errorf("error al leer el archivo %s: %w", filePath, err)
+		}
+		contents = append(contents, content)
+	}
+
+	return strings.Join(contents, "\n"), nil
+}
+
+// readFile obtiene el contenido de un archivo verificando que el usuario tenga permiso de lectura
+func readFile(filePath string, sb *structures.SuperBlock, partitionPath string, isRoot bool, uid int32, gid int32) (string, error) {
+	// Buscar el inodo del archivo
+	_, inode, err := sb.ResolvePath(partitionPath, filePath)
+	if err != nil {
+		return "", err
+	}
+
+	if inode.I_type[0] != '1' {
+		return "", errors.New("la ruta no es un archivo")
+	}
+
+	// El usuario root puede leer cualquier archivo
+	if !isRoot && !inode.HasPermission(uid, gid, 4) {
+		return "", errors.New("no tiene permiso de lectura")
+	}
+
+	return sb.ReadFileContent(partitionPath, inode)
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	structures "backend/structures"
+	utils "backend/utils"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CHECKDISK estructura que representa el comando checkdisk con sus parámetros
+type CHECKDISK struct {
+	path string // Ruta del archivo del disco
+}
+
+/*
+	checkdisk -path=/home/Disco1.mia
+*/
+
+// Parámetros aceptados por checkdisk
+var checkdiskSchema = Schema{
+	Command: "checkdisk",
+	Params: []Param{
+		{Name: "path", Required: true},
+	},
+}
+
+// ParseCheckdisk parsea el comando checkdisk y devuelve el reporte de la tabla de particiones
+func ParseCheckdisk(tokens []string) (string, error) {
+	// Validar los parámetros contra el esquema del comando
+	params, err := checkdiskSchema.Parse(tokens)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := &CHECKDISK{
+		path: params.String("path"),
+	}
+
+	// Verifica si el disco existe
+	if !utils.FileExists(cmd.path) {
+		return "", errors.New("el disco no existe")
+	}
+
+	// Ejecuta el comando checkdisk
+	return commandCheckdisk(cmd)
+}
+
+func commandCheckdisk(checkdisk *CHECKDISK) (string, error) {
+	// Crear una instancia de MBR
+	var mbr structures.MBR
+
+	// Deserializar la estructura MBR desde un archivo binario
+	err := mbr.Deserialize(checkdisk.path)
+	if err != nil {
+		return "", fmt.Errorf("error al leer el MBR: %w", err)
+	}
+
+	// Validar la tabla de particiones
+	problems := mbr.Validate(checkdisk.path)
+	if len(problems) == 0 {
+		return fmt.Sprintf("CHECKDISK: La tabla de particiones no tiene problemas\n"+
+			"-> Path: %s", checkdisk.path), nil
+	}
+
+	// Listar los problemas encontrados
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("CHECKDISK: Se encontraron %d problemas en la tabla de particiones\n", len(problems)))
+	result.WriteString(fmt.Sprintf("-> Path: %s", checkdisk.path))
+	for _, problem := range problems {
+		result.WriteString(fmt.Sprintf("\n-> %s", problem))
+	}
+
+	return result.String(), nil
+}
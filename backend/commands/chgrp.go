@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"fmt"
+)
+
+// CHGRP estructura que representa el comando chgrp con sus parámetros
+type CHGRP struct {
+	user string // Nombre del usuario
+	grp  string // Nuevo grupo del usuario
+}
+
+/*
+   chgrp -user=user1 -grp=grupo1
+*/
+
+// Parámetros aceptados por chgrp
+var chgrpSchema = Schema{
+	Command: "chgrp",
+	Params: []Param{
+		{Name: "user", Required: true},
+		{Name: "grp", Required: true},
+	},
+}
+
+func ParseChgrp(tokens []string) (string, error) {
+	// Validar los parámetros contra el esquema del comando
+	params, err := chgrpSchema.Parse(tokens)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := &CHGRP{
+		user: params.String("user"),
+		grp:  params.String("grp"),
+	}
+
+	// Ejecutar el comando chgrp
+	err = commandChgrp(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("CHGRP: Usuario %s movido al grupo %s", cmd.user, cmd.grp), nil
+}
+
+func commandChgrp(chgrp *CHGRP) error {
+	// Solo el usuario root puede cambiar el grupo de un usuario
+	err := requireRoot()
+	if err != nil {
+		return err
+	}
+
+	// Leer users.txt
+	users, err := loadUsersFile()
+	if err != nil {
+		return err
+	}
+
+	// El usuario y el nuevo grupo deben existir
+	user := users.FindUser(chgrp.user)
+	if user == nil {
+		return fmt.Errorf("el usuario %s no existe", chgrp.user)
+	}
+	if users.FindGroup(chgrp.grp) == nil {
+		return fmt.Errorf("el grupo %s no existe", chgrp.grp)
+	}
+
+	// Cambiar el grupo del usuario
+	user.Group = chgrp.grp
+
+	return users.save("chgrp", chgrp.user+","+chgrp.grp)
+}
\ No newline at end of file
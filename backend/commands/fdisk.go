@@ -1,131 +1,113 @@
 package commands
 
 import (
+	stores "backend/stores"
 	structures "backend/structures"
 	utils "backend/utils"
+	"encoding/binary" // Paquete para calcular el tamaño de las estructuras binarias
 	"errors"  // Paquete para manejar errores y crear nuevos errores con mensajes personalizados
 	"fmt"     // Paquete para formatear cadenas y realizar operaciones de entrada/salida
-	"regexp"  // Paquete para trabajar con expresiones regulares, útil para encontrar y manipular patrones en cadenas
-	"strconv" // Paquete para convertir cadenas a otros tipos de datos, como enteros
 	"strings" // Paquete para manipular cadenas, como unir, dividir, y modificar contenido de cadenas
 )
 
 // FDISK estructura que representa el comando fdisk con sus parámetros
 type FDISK struct {
 	size int    // Tamaño de la partición
-	unit string // Unidad de medida del tamaño (K o M)
+	unit string // Unidad de medida del tamaño (B, K o M)
 	fit  string // Tipo de ajuste (BF, FF, WF)
 	path string // Ruta del archivo del disco
 	typ  string // Tipo de partición (P, E, L)
 	name string // Nombre de la partición
+	del  string // Modo de eliminación de la partición (fast o full)
+	add  int    // Espacio a agregar (positivo) o quitar (negativo) de la partición
 }
 
 /*
 	fdisk -size=1 -type=L -unit=M -fit=BF -name="Particion3" -path="/home/keviin/University/PRACTICAS/MIA_LAB_S2_2024/CLASEEXTRA/disks/Disco1.mia"
 	fdisk -size=300 -path=/home/Disco1.mia -name=Particion1
 	fdisk -type=E -path=/home/Disco2.mia -Unit=K -name=Particion2 -size=300
+	fdisk -delete=full -name="Particion1" -path=/home/Disco1.mia
+	fdisk -add=-500 -unit=K -path=/home/Disco1.mia -name=Particion1
 */
 
+// Parámetros aceptados por fdisk
+var fdiskSchema = Schema{
+	Command: "fdisk",
+	Params: []Param{
+		{Name: "size", Type: ParamInt},
+		{Name: "unit", Allowed: []string{"B", "K", "M"}, Default: "M"},
+		{Name: "fit", Allowed: []string{"BF", "FF", "WF"}, Default: "WF"},
+		{Name: "path", Required: true},
+		{Name: "type", Allowed: []string{"P", "E", "L"}, Default: "P"},
+		{Name: "name", Required: true},
+		{Name: "delete", Allowed: []string{"fast", "full"}},
+		{Name: "add", Type: ParamInt},
+	},
+}
+
 // CommandFdisk parsea el comando fdisk y devuelve una instancia de FDISK
 func ParseFdisk(tokens []string) (string, error) {
-	cmd := &FDISK{} // Crea una nueva instancia de FDISK
-
-	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
-	args := strings.Join(tokens, " ")
-	// Expresión regular para encontrar los parámetros del comando fdisk
-	re := regexp.MustCompile(`-size=\d+|-unit=[kKmM]|-fit=[bBfF]{2}|-path="[^"]+"|-path=[^\s]+|-type=[pPeElL]|-name="[^"]+"|-name=[^\s]+`)
-	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
-	matches := re.FindAllString(args, -1)
-
-	// Itera sobre cada coincidencia encontrada
-	for _, match := range matches {
-		// Divide cada parte en clave y valor usando "=" como delimitador
-		kv := strings.SplitN(match, "=", 2)
-		if len(kv) != 2 {
-			return "", fmt.Errorf("formato de parámetro inválido: %s", match)
-		}
-		key, value := strings.ToLower(kv[0]), kv[1]
-
-		// Remove quotes from value if present
-		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
-			value = strings.Trim(value, "\"")
-		}
-
-		// Switch para manejar diferentes parámetros
-		switch key {
-		case "-size":
-			// Convierte el valor del tamaño a un entero
-			size, err := strconv.Atoi(value)
-			if err != nil || size <= 0 {
-				return "", errors.New("el tamaño debe ser un número entero positivo")
-			}
-			cmd.size = size
-		case "-unit":
-			// Verifica que la unidad sea "K" o "M"
-			if value != "B" && value != "K" && value != "M" {
-				return "", errors.New("la unidad debe ser B, K o M")
-			}
-			cmd.unit = strings.ToUpper(value)
-		case "-fit":
-			// Verifica que el ajuste sea "BF", "FF" o "WF"
-			value = strings.ToUpper(value)
-			if value != "BF" && value != "FF" && value != "WF" {
-				return "", errors.New("el ajuste debe ser BF, FF o WF")
-			}
-			cmd.fit = value
-		case "-path":
-			// Verifica que el path no esté vacío
-			if value == "" {
-				return "", errors.New("el path no puede estar vacío")
-			}
-			cmd.path = value
-		case "-type":
-			// Verifica que el tipo sea "P", "E" o "L"
-			value = strings.ToUpper(value)
-			if value != "P" && value != "E" && value != "L" {
-				return "", errors.New("el tipo debe ser P, E o L")
-			}
-			cmd.typ = value
-		case "-name":
-			// Verifica que el nombre no esté vacío
-			if value == "" {
-				return "", errors.New("el nombre no puede estar vacío")
-			}
-			cmd.name = value
-		default:
-			// Si el parámetro no es reconocido, devuelve un error
-			return "", fmt.Errorf("parámetro desconocido: %s", key)
-		}
+	// Validar los parámetros contra el esquema del comando
+	params, err := fdiskSchema.Parse(tokens)
+	if err != nil {
+		return "", err
 	}
 
-	// Verifica que los parámetros -size, -path y -name hayan sido proporcionados
-	if cmd.size == 0 {
-		return "", errors.New("faltan parámetros requeridos: -size")
-	}
-	if cmd.path == "" {
-		return "", errors.New("faltan parámetros requeridos: -path")
+	cmd := &FDISK{
+		size: params.Int("size"),
+		unit: params.String("unit"),
+		fit:  params.String("fit"),
+		path: params.String("path"),
+		typ:  params.String("type"),
+		name: params.String("name"),
+		del:  params.String("delete"),
+		add:  params.Int("add"),
 	}
-	if cmd.name == "" {
-		return "", errors.New("faltan parámetros requeridos: -name")
+
+	// Verifica que -add cambie el tamaño de la partición
+	if params.Has("add") && cmd.add == 0 {
+		return "", errors.New("el valor de -add debe ser un número entero distinto de 0")
 	}
 
-	// Si no se proporcionó la unidad, se establece por defecto a "M"
-	if cmd.unit == "" {
-		cmd.unit = "M"
+	// Si se proporcionó -delete, se elimina la partición en lugar de crearla
+	if cmd.del != "" {
+		err := deletePartition(cmd)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("FDISK: Partición eliminada exitosamente\n"+
+			"-> Path: %s\n"+
+			"-> Nombre: %s\n"+
+			"-> Modo: %s",
+			cmd.path, cmd.name, cmd.del), nil
 	}
 
-	// Si no se proporcionó el ajuste, se establece por defecto a "FF"
-	if cmd.fit == "" {
-		cmd.fit = "WF"
+	// Si se proporcionó -add, se modifica el tamaño de la partición en lugar de crearla
+	if cmd.add != 0 {
+		newSize, err := resizePartition(cmd)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("FDISK: Tamaño de la partición modificado exitosamente\n"+
+			"-> Path: %s\n"+
+			"-> Nombre: %s\n"+
+			"-> Cambio: %d%s\n"+
+			"-> Nuevo tamaño: %d bytes",
+			cmd.path, cmd.name, cmd.add, cmd.unit, newSize), nil
 	}
 
-	// Si no se proporcionó el tipo, se establece por defecto a "P"
-	if cmd.typ == "" {
-		cmd.typ = "P"
+	// Verifica que el parámetro -size haya sido proporcionado
+	if !params.Has("size") {
+		return "", errors.New("faltan parámetros requeridos: -size")
+	}
+	if cmd.size <= 0 {
+		return "", errors.New("el tamaño debe ser un número entero positivo")
 	}
 
 	// Crear la partición con los parámetros proporcionados
-	err := commandFdisk(cmd)
+	err = commandFdisk(cmd)
 	if err != nil {
 		return "", err
 	}
@@ -156,9 +138,19 @@ func commandFdisk(fdisk *FDISK) error {
 			return err
 		}
 	} else if fdisk.typ == "E" {
-		fmt.Println("Creando partición extendida...") // Les toca a ustedes implementar la partición extendida
+		// Crear partición extendida
+		err = createExtendedPartition(fdisk, sizeBytes)
+		if err != nil {
+			fmt.Println("Error creando partición extendida:", err)
+			return err
+		}
 	} else if fdisk.typ == "L" {
-		fmt.Println("Creando partición lógica...") // Les toca a ustedes implementar la partición lógica
+		// Crear partición lógica
+		err = createLogicalPartition(fdisk, sizeBytes)
+		if err != nil {
+			fmt.Println("Error creando partición lógica:", err)
+			return err
+		}
 	}
 
 	return nil
@@ -180,10 +172,15 @@ func createPrimaryPartition(fdisk *FDISK, sizeBytes int) error {
 	fmt.Println("\nMBR original:")
 	mbr.PrintMBR()
 
-	// Obtener la primera partición disponible
-	availablePartition, startPartition, indexPartition := mbr.GetFirstAvailablePartition()
-	if availablePartition == nil {
-		fmt.Println("No hay particiones disponibles.")
+	// Verificar que no exista otra partición con el mismo nombre
+	if partition, _ := mbr.GetPartitionByName(fdisk.name, fdisk.path); partition != nil {
+		return errors.New("ya existe una partición con ese nombre en el disco")
+	}
+
+	// Obtener la partición disponible según el ajuste del disco
+	availablePartition, startPartition, indexPartition, err := mbr.GetAvailablePartition(sizeBytes)
+	if err != nil {
+		return err
 	}
 
 	/* SOLO PARA VERIFICACIÓN */
@@ -210,8 +207,461 @@ func createPrimaryPartition(fdisk *FDISK, sizeBytes int) error {
 	// Serializar el MBR en el archivo binario
 	err = mbr.Serialize(fdisk.path)
 	if err != nil {
-		fmt.Println("Error:", err)
+		return fmt.Errorf("error al escribir el MBR: %w", err)
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+func createExtendedPartition(fdisk *FDISK, sizeBytes int) error {
+	// Crear una instancia de MBR
+	var mbr structures.MBR
+
+	// Deserializar la estructura MBR desde un archivo binario
+	err := mbr.Deserialize(fdisk.path)
+	if err != nil {
+		fmt.Println("Error deserializando el MBR:", err)
+		return err
+	}
+
+	// Verificar que no exista otra partición con el mismo nombre
+	if partition, _ := mbr.GetPartitionByName(fdisk.name, fdisk.path); partition != nil {
+		return errors.New("ya existe una partición con ese nombre en el disco")
+	}
+
+	// Verificar que no exista otra partición extendida en el disco
+	if extended, _ := mbr.GetExtendedPartition(); extended != nil {
+		return errors.New("ya existe una partición extendida en el disco")
+	}
+
+	// Obtener la partición disponible según el ajuste del disco
+	availablePartition, startPartition, indexPartition, err := mbr.GetAvailablePartition(sizeBytes)
+	if err != nil {
+		return err
+	}
+
+	// Crear la partición con los parámetros proporcionados
+	availablePartition.CreatePartition(startPartition, sizeBytes, fdisk.typ, fdisk.fit, fdisk.name)
+
+	// Crear el EBR inicial de la partición extendida, aún sin particiones lógicas
+	ebr := &structures.EBR{}
+	ebr.CreateEBR(startPartition, 0, fdisk.fit, "")
+
+	/* SOLO PARA VERIFICACIÓN */
+	// Print para verificar que el EBR se haya creado correctamente
+	fmt.Println("\nEBR inicial de la partición extendida:")
+	ebr.PrintEBR()
+
+	// Colocar la partición en el MBR
+	mbr.Mbr_partitions[indexPartition] = *availablePartition
+
+	// Imprimir las particiones del MBR
+	fmt.Println("\nParticiones del MBR:")
+	mbr.PrintPartitions()
+
+	// Serializar el MBR validándolo con el EBR inicial, que aún no está en el disco
+	err = mbr.SerializeWithChain(fdisk.path, []structures.EBR{*ebr})
+	if err != nil {
+		return fmt.Errorf("error al escribir el MBR: %w", err)
+	}
+
+	// El EBR se escribe solo si el MBR fue aceptado, para no dejar un EBR suelto en el disco
+	err = ebr.Serialize(fdisk.path, int64(startPartition))
+	if err != nil {
+		return fmt.Errorf("error al escribir el EBR: %w", err)
+	}
+
+	return nil
+}
+
+func createLogicalPartition(fdisk *FDISK, sizeBytes int) error {
+	// Crear una instancia de MBR
+	var mbr structures.MBR
+
+	// Deserializar la estructura MBR desde un archivo binario
+	err := mbr.Deserialize(fdisk.path)
+	if err != nil {
+		fmt.Println("Error deserializando el MBR:", err)
+		return err
+	}
+
+	// Las particiones lógicas solo pueden crearse dentro de una partición extendida
+	extended, _ := mbr.GetExtendedPartition()
+	if extended == nil {
+		return errors.New("no existe una partición extendida en el disco")
+	}
+
+	// Verificar que no exista otra partición con el mismo nombre
+	if partition, _ := mbr.GetPartitionByName(fdisk.name, fdisk.path); partition != nil {
+		return errors.New("ya existe una partición con ese nombre en el disco")
+	}
+
+	// Obtener la cadena de EBRs de la partición extendida
+	ebrs, err := mbr.GetLogicalPartitions(fdisk.path)
+	if err != nil {
+		return err
+	}
+
+	// Buscar un espacio libre según el ajuste de la partición extendida, contando el EBR de la nueva partición
+	ebrSize := int32(binary.Size(structures.EBR{}))
+	spaces := structures.GetLogicalFreeSpaces(extended, ebrs)
+	space := structures.SelectFreeSpace(spaces, ebrSize+int32(sizeBytes), extended.Part_fit[0])
+	if space == nil {
+		return errors.New("no hay espacio suficiente en la partición extendida")
+	}
+
+	// Si el espacio está al inicio de la extendida se reutiliza el EBR inicial vacío
+	if space.Start == extended.Part_start {
+		head := &ebrs[0]
+		next := head.Part_next
+		head.CreateEBR(int(space.Start), sizeBytes, fdisk.fit, fdisk.name)
+		head.Part_next = next
+
+		/* SOLO PARA VERIFICACIÓN */
+		fmt.Println("\nEBR creado:")
+		head.PrintEBR()
+
+		// Validar la tabla de particiones antes de escribir
+		err = structures.PartitionTableError(structures.ValidatePartitionTable(&mbr, ebrs))
+		if err != nil {
+			return err
+		}
+
+		return head.Serialize(fdisk.path, int64(head.Part_start))
+	}
+
+	// Buscar el EBR anterior al espacio libre para enlazar el nuevo EBR después de él
+	prevIndex := 0
+	for i := range ebrs {
+		if ebrs[i].Part_start < space.Start {
+			prevIndex = i
+		}
+	}
+	prev := &ebrs[prevIndex]
+
+	// Crear el nuevo EBR en el espacio libre
+	ebr := &structures.EBR{}
+	ebr.CreateEBR(int(space.Start), sizeBytes, fdisk.fit, fdisk.name)
+	ebr.Part_next = prev.Part_next
+	prev.Part_next = ebr.Part_start
+
+	/* SOLO PARA VERIFICACIÓN */
+	fmt.Println("\nEBR creado:")
+	ebr.PrintEBR()
+
+	// Validar la tabla de particiones con el nuevo EBR antes de escribir
+	chain := append(append(append([]structures.EBR{}, ebrs[:prevIndex+1]...), *ebr), ebrs[prevIndex+1:]...)
+	err = structures.PartitionTableError(structures.ValidatePartitionTable(&mbr, chain))
+	if err != nil {
+		return err
+	}
+
+	// Serializar el nuevo EBR y actualizar el enlace del anterior
+	err = ebr.Serialize(fdisk.path, int64(ebr.Part_start))
+	if err != nil {
+		return fmt.Errorf("error al escribir el EBR: %w", err)
+	}
+	err = prev.Serialize(fdisk.path, int64(prev.Part_start))
+	if err != nil {
+		return fmt.Errorf("error al escribir el EBR: %w", err)
+	}
+
+	return nil
+}
+
+func deletePartition(fdisk *FDISK) error {
+	// Crear una instancia de MBR
+	var mbr structures.MBR
+
+	// Deserializar la estructura MBR desde un archivo binario
+	err := mbr.Deserialize(fdisk.path)
+	if err != nil {
+		fmt.Println("Error deserializando el MBR:", err)
+		return err
+	}
+
+	// Buscar la partición con el nombre especificado
+	partition, indexPartition := mbr.GetPartitionByName(fdisk.name, fdisk.path)
+	if partition == nil {
+		return errors.New("la partición no existe")
+	}
+
+	// No se puede eliminar una partición montada
+	if partition.Part_status[0] == '1' {
+		return errors.New("no se puede eliminar una partición montada")
+	}
+
+	// Las particiones lógicas se eliminan de la cadena de EBRs
+	if partition.Part_type[0] == 'L' {
+		return deleteLogicalPartition(&mbr, fdisk)
+	}
+
+	// Si es la extendida, ninguna de sus particiones lógicas puede estar montada
+	if partition.Part_type[0] == 'E' {
+		ebrs, err := mbr.GetLogicalPartitions(fdisk.path)
+		if err != nil {
+			return err
+		}
+		for _, ebr := range ebrs {
+			if ebr.Part_mount[0] == '1' {
+				return errors.New("no se puede eliminar la partición extendida porque tiene particiones lógicas montadas")
+			}
+		}
+	}
+
+	// En modo full se rellena con ceros el espacio de la partición (incluye los EBRs de la extendida)
+	if fdisk.del == "full" {
+		err = utils.WriteZeros(fdisk.path, int64(partition.Part_start), int64(partition.Part_size))
+		if err != nil {
+			return fmt.Errorf("error al limpiar la partición: %w", err)
+		}
+	}
+
+	// Liberar la entrada de la partición en el MBR
+	mbr.Mbr_partitions[indexPartition].DeletePartition()
+
+	// Imprimir las particiones del MBR
+	fmt.Println("\nParticiones del MBR:")
+	mbr.PrintPartitions()
+
+	// Serializar el MBR en el archivo binario
+	err = mbr.Serialize(fdisk.path)
+	if err != nil {
+		return fmt.Errorf("error al escribir el MBR: %w", err)
+	}
+
+	return nil
+}
+
+func deleteLogicalPartition(mbr *structures.MBR, fdisk *FDISK) error {
+	// Obtener la cadena de EBRs de la partición extendida
+	ebrs, err := mbr.GetLogicalPartitions(fdisk.path)
+	if err != nil {
+		return err
+	}
+
+	// Buscar la posición del EBR dentro de la cadena
+	index := findLogicalPartition(ebrs, fdisk.name)
+	if index == -1 {
+		return errors.New("la partición lógica no existe")
+	}
+	ebr := &ebrs[index]
+	ebrSize := int32(binary.Size(structures.EBR{}))
+
+	// En modo full se rellenan con ceros los datos de la partición lógica
+	if fdisk.del == "full" {
+		err = utils.WriteZeros(fdisk.path, int64(ebr.Part_start+ebrSize), int64(ebr.Part_s))
+		if err != nil {
+			return fmt.Errorf("error al limpiar la partición: %w", err)
+		}
+	}
+
+	// El EBR inicial no se puede quitar de la cadena, solo se deja vacío
+	if index == 0 {
+		next := ebr.Part_next
+		ebr.CreateEBR(int(ebr.Part_start), 0, string(ebr.Part_fit[:]), "")
+		ebr.Part_next = next
+
+		return ebr.Serialize(fdisk.path, int64(ebr.Part_start))
+	}
+
+	// Desenlazar el EBR de la cadena
+	prev := &ebrs[index-1]
+	prev.Part_next = ebr.Part_next
+	err = prev.Serialize(fdisk.path, int64(prev.Part_start))
+	if err != nil {
+		return fmt.Errorf("error al escribir el EBR: %w", err)
+	}
+
+	// En modo full también se limpia el EBR eliminado
+	if fdisk.del == "full" {
+		err = utils.WriteZeros(fdisk.path, int64(ebr.Part_start), int64(ebrSize))
+		if err != nil {
+			return fmt.Errorf("error al limpiar el EBR: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func resizePartition(fdisk *FDISK) (int32, error) {
+	// Convertir el espacio a agregar o quitar a bytes
+	addBytes, err := utils.ConvertToBytes(fdisk.add, fdisk.unit)
+	if err != nil {
+		fmt.Println("Error converting size:", err)
+		return 0, err
+	}
+
+	// Crear una instancia de MBR
+	var mbr structures.MBR
+
+	// Deserializar la estructura MBR desde un archivo binario
+	err = mbr.Deserialize(fdisk.path)
+	if err != nil {
+		fmt.Println("Error deserializando el MBR:", err)
+		return 0, err
+	}
+
+	// Buscar la partición con el nombre especificado
+	partition, indexPartition := mbr.GetPartitionByName(fdisk.name, fdisk.path)
+	if partition == nil {
+		return 0, errors.New("la partición no existe")
+	}
+
+	// Las particiones lógicas se modifican en su EBR
+	if partition.Part_type[0] == 'L' {
+		return resizeLogicalPartition(&mbr, fdisk, int32(addBytes))
+	}
+
+	// Verificar que el tamaño resultante sea válido
+	newSize := partition.Part_size + int32(addBytes)
+	if newSize <= 0 {
+		return 0, errors.New("el tamaño resultante de la partición debe ser mayor a 0")
+	}
+	if addBytes < 0 {
+		err = checkPartitionShrink(&mbr, fdisk.path, partition, newSize)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if addBytes > 0 {
+		// El crecimiento solo puede ocupar el espacio libre contiguo, hasta la siguiente partición o el final del disco
+		limit := mbr.Mbr_size
+		for i, other := range mbr.Mbr_partitions {
+			if i != indexPartition && other.Part_start != -1 && other.Part_start > partition.Part_start && other.Part_start < limit {
+				limit = other.Part_start
+			}
+		}
+		if partition.Part_start+newSize > limit {
+			return 0, errors.New("no hay espacio libre suficiente después de la partición")
+		}
+	} else if partition.Part_type[0] == 'E' {
+		// Al reducir la extendida no se puede dejar fuera ninguna partición lógica
+		ebrs, err := mbr.GetLogicalPartitions(fdisk.path)
+		if err != nil {
+			return 0, err
+		}
+		ebrSize := int32(binary.Size(structures.EBR{}))
+		end := partition.Part_start + ebrSize
+		for _, ebr := range ebrs {
+			if ebr.Part_s > 0 && ebr.Part_start+ebrSize+ebr.Part_s > end {
+				end = ebr.Part_start + ebrSize + ebr.Part_s
+			}
+		}
+		if partition.Part_start+newSize < end {
+			return 0, errors.New("no se puede reducir la partición extendida sin afectar sus particiones lógicas")
+		}
+	}
+
+	// Actualizar el tamaño de la partición en el MBR
+	mbr.Mbr_partitions[indexPartition].Part_size = newSize
+
+	// Imprimir las particiones del MBR
+	fmt.Println("\nParticiones del MBR:")
+	mbr.PrintPartitions()
+
+	// Serializar el MBR en el archivo binario
+	err = mbr.Serialize(fdisk.path)
+	if err != nil {
+		return 0, fmt.Errorf("error al escribir el MBR: %w", err)
+	}
+
+	return newSize, nil
+}
+
+func resizeLogicalPartition(mbr *structures.MBR, fdisk *FDISK, addBytes int32) (int32, error) {
+	// Obtener la cadena de EBRs de la partición extendida
+	ebrs, err := mbr.GetLogicalPartitions(fdisk.path)
+	if err != nil {
+		return 0, err
+	}
+
+	// Buscar el EBR de la partición lógica
+	index := findLogicalPartition(ebrs, fdisk.name)
+	if index == -1 {
+		return 0, errors.New("la partición lógica no existe")
+	}
+	ebr := &ebrs[index]
+
+	// Verificar que el tamaño resultante sea válido
+	newSize := ebr.Part_s + addBytes
+	if newSize <= 0 {
+		return 0, errors.New("el tamaño resultante de la partición debe ser mayor a 0")
+	}
+	if addBytes < 0 {
+		err = checkPartitionShrink(mbr, fdisk.path, ebr.ToPartition(), newSize)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if addBytes > 0 {
+		// El crecimiento llega hasta el siguiente EBR o el final de la partición extendida
+		extended, _ := mbr.GetExtendedPartition()
+		limit := extended.Part_start + extended.Part_size
+		if ebr.Part_next != -1 {
+			limit = ebr.Part_next
+		}
+		if ebr.Part_start+int32(binary.Size(structures.EBR{}))+newSize > limit {
+			return 0, errors.New("no hay espacio libre suficiente después de la partición")
+		}
+	}
+
+	// Actualizar el tamaño en el EBR
+	ebr.Part_s = newSize
+
+	/* SOLO PARA VERIFICACIÓN */
+	fmt.Println("\nEBR modificado:")
+	ebr.PrintEBR()
+
+	// Validar la tabla de particiones antes de escribir
+	err = structures.PartitionTableError(structures.ValidatePartitionTable(mbr, ebrs))
+	if err != nil {
+		return 0, err
+	}
+
+	// Serializar el EBR en su posición
+	err = ebr.Serialize(fdisk.path, int64(ebr.Part_start))
+	if err != nil {
+		return 0, fmt.Errorf("error al escribir el EBR: %w", err)
+	}
+
+	return newSize, nil
+}
+
+// findLogicalPartition busca la posición de una partición lógica dentro de la cadena de EBRs
+// checkPartitionShrink verifica que una partición se pueda reducir a newSize bytes: no puede estar montada
+// y, si está formateada, sus tablas de inodos y bloques deben caber en el nuevo tamaño
+func checkPartitionShrink(mbr *structures.MBR, path string, partition *structures.Partition, newSize int32) error {
+	if stores.IsPartitionMounted(path, mbr, partition) {
+		return errors.New("no se puede reducir una partición montada, desmóntela primero")
+	}
+
+	var sb structures.SuperBlock
+	err := sb.Deserialize(path, int64(partition.Part_start))
+	if err != nil || sb.S_magic != 0xEF53 {
+		return nil
+	}
+
+	// El último bloque del sistema de archivos marca hasta dónde llegan los datos
+	dataEnd := int64(sb.S_block_start) + int64(sb.TotalBlocks())*int64(sb.S_block_size)
+	if dataEnd > int64(partition.Part_start)+int64(newSize) {
+		return fmt.Errorf("no se puede reducir la partición: su sistema de archivos ocupa %d bytes", dataEnd-int64(partition.Part_start))
+	}
+	return nil
+}
+
+func findLogicalPartition(ebrs []structures.EBR, name string) int {
+	inputName := strings.Trim(name, "\x00 ")
+	for i := range ebrs {
+		// El EBR inicial vacío no representa una partición lógica
+		if ebrs[i].Part_s <= 0 {
+			continue
+		}
+		ebrName := strings.Trim(string(ebrs[i].Part_name[:]), "\x00 ")
+		if strings.EqualFold(ebrName, inputName) {
+			return i
+		}
+	}
+	return -1
+}
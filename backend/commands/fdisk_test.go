@@ -0,0 +1,36 @@
+package commands
+
+import (
+	stores "backend/stores"
+	"strings"
+	"testing"
+)
+
+func TestFdiskRefusesUnsafeShrink(t *testing.T) {
+	id := newTestPartition(t)
+	diskPath := stores.MountedPartitions[id].Path
+	shrink := []string{"-add=-10", "-unit=K", "-path=" + diskPath, "-name=Part1"}
+
+	// Montada: no se puede reducir
+	_, err := ParseFdisk(shrink)
+	if err == nil || !strings.Contains(err.Error(), "montada") {
+		t.Fatalf("fdisk -add sobre una partición montada = %v", err)
+	}
+
+	// Desmontada pero formateada: las tablas de inodos y bloques llegan hasta el final
+	if _, err := ParseUnmount([]string{"-id=" + id}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = ParseFdisk(shrink)
+	if err == nil || !strings.Contains(err.Error(), "sistema de archivos") {
+		t.Fatalf("fdisk -add sobre una partición formateada = %v", err)
+	}
+
+	// Una partición sin formatear sí se puede reducir
+	if _, err := ParseFdisk([]string{"-size=100", "-unit=K", "-path=" + diskPath, "-name=Part2"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseFdisk([]string{"-add=-10", "-unit=K", "-path=" + diskPath, "-name=Part2"}); err != nil {
+		t.Fatalf("fdisk -add sobre una partición sin formatear: %v", err)
+	}
+}
\ No newline at end of file
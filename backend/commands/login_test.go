@@ -0,0 +1,80 @@
+package commands
+
+import (
+	stores "backend/stores"
+	structures "backend/structures"
+	"path/filepath"
+	"testing"
+)
+
+// newTestPartition crea un disco temporal con una partición EXT2 montada y formateada, y devuelve su id
+func newTestPartition(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	previousStatePath, previousMounted := stores.MountStatePath, stores.MountedPartitions
+	stores.MountStatePath = filepath.Join(dir, "mounted_partitions.json")
+	stores.MountedPartitions = make(map[string]*stores.MountedPartition)
+	t.Cleanup(func() {
+		stores.MountStatePath, stores.MountedPartitions = previousStatePath, previousMounted
+		stores.Auth.Logout()
+	})
+
+	// Crear, montar y formatear una partición
+	diskPath := filepath.Join(dir, "disco.mia")
+	steps := []func([]string) (string, error){ParseMkdisk, ParseFdisk, ParseMount}
+	args := [][]string{
+		{"-size=1", "-unit=M", "-path=" + diskPath},
+		{"-size=500", "-unit=K", "-path=" + diskPath, "-name=Part1"},
+		{"-path=" + diskPath, "-name=Part1"},
+	}
+	for i, step := range steps {
+		if _, err := step(args[i]); err != nil {
+			t.Fatalf("%v: %v", args[i], err)
+		}
+	}
+	var id string
+	for mountedID, mounted := range stores.MountedPartitions {
+		if mounted.Path == diskPath {
+			id = mountedID
+		}
+	}
+	if _, err := ParseMkfs([]string{"-id=" + id}); err != nil {
+		t.Fatalf("mkfs: %v", err)
+	}
+	return id
+}
+
+func TestLoginAcceptsPlainPassword(t *testing.T) {
+	id := newTestPartition(t)
+
+	// Dejar la contraseña de root en texto plano, como en los discos anteriores al cifrado
+	sb, _, partitionPath, err := stores.GetMountedPartitionSuperblock(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err := structures.ParseUsersFile("1,G,root\n1,U,root,root,123\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sb.WriteUsersFile(partitionPath, users); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseLogin([]string{"-user=root", "-pass=123", "-id=" + id}); err != nil {
+		t.Fatalf("login: %v", err)
+	}
+
+	// Iniciar sesión no modifica users.txt
+	sb, _, partitionPath, err = stores.GetMountedPartitionSuperblock(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err = sb.ReadUsersFile(partitionPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root := users.FindUser("root"); root.Password != "123" {
+		t.Fatalf("login no debería reescribir la contraseña de root: %q", root.Password)
+	}
+}
\ No newline at end of file
@@ -4,18 +4,21 @@ import (
     "backend/stores"
     "errors"
     "fmt"
-    "strings"
 )
 
+// logout no acepta parámetros
+var logoutSchema = Schema{Command: "logout"}
+
 // ParseLogout parsea el comando logout y devuelve una instancia de LOGOUT
 func ParseLogout(tokens []string) (string, error) {
     // Verificar que no se hayan pasado parámetros
-    if len(tokens) > 0 {
-        return "", fmt.Errorf("el comando 'logout' no acepta parámetros: %s", strings.Join(tokens, " "))
+    _, err := logoutSchema.Parse(tokens)
+    if err != nil {
+        return "", err
     }
 
     // Ejecutar el comando logout
-    err := commandLogout()
+    err = commandLogout()
     if err != nil {
         return "", err
     }
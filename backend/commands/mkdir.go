@@ -3,11 +3,8 @@ package commands
 import (
 	stores "backend/stores"
 	structures "backend/structures"
-	utils "backend/utils"
 	"errors"
 	"fmt"
-	"regexp"
-	"strings"
 )
 
 // MKDIR estructura que representa el comando mkdir con sus parámetros
@@ -21,59 +18,29 @@ type MKDIR struct {
    mkdir -path="/home/mis documentos/archivos clases"
 */
 
-func ParseMkdir(tokens []string) (string, error) {
-	cmd := &MKDIR{} // Crea una nueva instancia de MKDIR
-
-	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
-	args := strings.Join(tokens, " ")
-	// Expresión regular para encontrar los parámetros del comando mkdir
-	re := regexp.MustCompile(`-path=[^\s]+|-p`)
-	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
-	matches := re.FindAllString(args, -1)
-
-	// Verificar que todos los tokens fueron reconocidos por la expresión regular
-	if len(matches) != len(tokens) {
-		// Identificar el parámetro inválido
-		for _, token := range tokens {
-			if !re.MatchString(token) {
-				return "", fmt.Errorf("parámetro inválido: %s", token)
-			}
-		}
-	}
+// Parámetros aceptados por mkdir
+var mkdirSchema = Schema{
+	Command: "mkdir",
+	Params: []Param{
+		{Name: "path", Required: true},
+		{Name: "p", Type: ParamFlag},
+	},
+}
 
-	// Itera sobre cada coincidencia encontrada
-	for _, match := range matches {
-		// Divide cada parte en clave y valor usando "=" como delimitador
-		kv := strings.SplitN(match, "=", 2)
-		key := strings.ToLower(kv[0])
-
-		// Switch para manejar diferentes parámetros
-		switch key {
-		case "-path":
-			if len(kv) != 2 {
-				return "", fmt.Errorf("formato de parámetro inválido: %s", match)
-			}
-			value := kv[1]
-			// Remove quotes from value if present
-			if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
-				value = strings.Trim(value, "\"")
-			}
-			cmd.path = value
-		case "-p":
-			cmd.p = true
-		default:
-			// Si el parámetro no es reconocido, devuelve un error
-			return "", fmt.Errorf("parámetro desconocido: %s", key)
-		}
+func ParseMkdir(tokens []string) (string, error) {
+	// Validar los parámetros contra el esquema del comando
+	params, err := mkdirSchema.Parse(tokens)
+	if err != nil {
+		return "", err
 	}
 
-	// Verifica que el parámetro -path haya sido proporcionado
-	if cmd.path == "" {
-		return "", errors.New("faltan parámetros requeridos: -path")
+	cmd := &MKDIR{
+		path: params.String("path"),
+		p:    params.Flag("p"),
 	}
 
 	// Aquí se puede agregar la lógica para ejecutar el comando mkdir con los parámetros proporcionados
-	err := commandMkdir(cmd)
+	err = commandMkdir(cmd)
 	if err != nil {
 		return "", err
 	}
@@ -99,31 +66,26 @@ func commandMkdir(mkdir *MKDIR) error {
 	}
 
 	// Crear el directorio
-	err = createDirectory(mkdir.path, partitionSuperblock, partitionPath, mountedPartition)
-	if err != nil {
-		err = fmt.Errorf("error al crear el directorio: %w", err)
-	}
-
-	return err
+	return createDirectory(mkdir, partitionSuperblock, partitionPath, mountedPartition)
 }
 
-func createDirectory(dirPath string, sb *structures.SuperBlock, partitionPath string, mountedPartition *structures.Partition) error {
-	fmt.Println("\nCreando directorio:", dirPath)
+func createDirectory(mkdir *MKDIR, sb *structures.SuperBlock, partitionPath string, mountedPartition *structures.Partition) error {
+	fmt.Println("\nCreando directorio:", mkdir.path)
 
-	parentDirs, destDir := utils.GetParentDirectories(dirPath)
-	fmt.Println("\nDirectorios padres:", parentDirs)
-	fmt.Println("Directorio destino:", destDir)
+	// La carpeta pertenece al usuario logueado
+	uid, gid := stores.Auth.GetUserIDs()
 
-	// Crear el directorio segun el path proporcionado
-	err := sb.CreateFolder(partitionPath, parentDirs, destDir)
+	// Crear el directorio segun el path proporcionado, y sus padres si se indicó -p
+	err := sb.CreateFolder(partitionPath, mkdir.path, mkdir.p, uid, gid)
 	if err != nil {
+		// Guardar el superbloque aunque falle, ya que el inodo y los bloques liberados cambian sus contadores
+		serializeErr := sb.Serialize(partitionPath, int64(mountedPartition.Part_start))
+		if serializeErr != nil {
+			return fmt.Errorf("error al crear el directorio: %w (error al serializar el superbloque: %v)", err, serializeErr)
+		}
 		return fmt.Errorf("error al crear el directorio: %w", err)
 	}
 
-	// Imprimir inodos y bloques
-	sb.PrintInodes(partitionPath)
-	sb.PrintBlocks(partitionPath)
-
 	// Serializar el superbloque
 	err = sb.Serialize(partitionPath, int64(mountedPartition.Part_start))
 	if err != nil {
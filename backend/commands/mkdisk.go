@@ -8,9 +8,6 @@ import (
 	"math/rand"     // Paquete para generar números aleatorios
 	"os"            // Paquete para interactuar con el sistema operativo
 	"path/filepath" // Paquete para trabajar con rutas de archivos y directorios
-	"regexp"        // Paquete para trabajar con expresiones regulares, útil para encontrar y manipular patrones en cadenas
-	"strconv"       // Paquete para convertir cadenas a otros tipos de datos, como enteros
-	"strings"       // Paquete para manipular cadenas, como unir, dividir, y modificar contenido de cadenas
 	"time"
 )
 
@@ -29,89 +26,38 @@ type MKDISK struct {
    mkdisk -size=10 -path="/home/mis discos/Disco4.mia"
 */
 
-func ParseMkdisk(tokens []string) (string, error) {
-    cmd := &MKDISK{} // Crea una nueva instancia de MKDISK
-
-    // Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
-    args := strings.Join(tokens, " ")
-    // Expresión regular para encontrar los parámetros válidos del comando mkdisk
-    re := regexp.MustCompile(`-size=\d+|-unit=[kKmM]|-fit=[bBfFwW]{2}|-path="[^"]+"|-path=[^\s]+`)
-    // Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
-    matches := re.FindAllString(args, -1)
-
-    // Verificar que todos los tokens fueron reconocidos por la expresión regular
-    if len(matches) != len(tokens) {
-        // Identificar el parámetro inválido
-        for _, token := range tokens {
-            if !re.MatchString(token) {
-                return "", fmt.Errorf("parámetro inválido: %s", token)
-            }
-        }
-    }
-
-    // Itera sobre cada coincidencia encontrada
-    for _, match := range matches {
-        // Divide cada parte en clave y valor usando "=" como delimitador
-        kv := strings.SplitN(match, "=", 2)
-        if len(kv) != 2 {
-            return "", fmt.Errorf("formato de parámetro inválido: %s", match)
-        }
-        key, value := strings.ToLower(kv[0]), kv[1]
-
-        // Remove quotes from value if present
-        if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
-            value = strings.Trim(value, "\"")
-        }
-
-        // Switch para manejar diferentes parámetros
-        switch key {
-        case "-size":
-            size, err := strconv.Atoi(value)
-            if err != nil || size <= 0 {
-                return "", errors.New("el tamaño debe ser un número entero positivo")
-            }
-            cmd.size = size
-        case "-unit":
-            if value != "K" && value != "M" {
-                return "", errors.New("la unidad debe ser K o M")
-            }
-            cmd.unit = strings.ToUpper(value)
-        case "-fit":
-            value = strings.ToUpper(value)
-            if value != "BF" && value != "FF" && value != "WF" {
-                return "", errors.New("el ajuste debe ser BF, FF o WF")
-            }
-            cmd.fit = value
-        case "-path":
-            if value == "" {
-                return "", errors.New("el path no puede estar vacío")
-            }
-            cmd.path = value
-        default:
-            return "", fmt.Errorf("parámetro desconocido: %s", key)
-        }
-    }
+// Parámetros aceptados por mkdisk
+var mkdiskSchema = Schema{
+    Command: "mkdisk",
+    Params: []Param{
+        {Name: "size", Type: ParamInt, Required: true},
+        {Name: "unit", Allowed: []string{"K", "M"}, Default: "M"},
+        {Name: "fit", Allowed: []string{"BF", "FF", "WF"}, Default: "FF"},
+        {Name: "path", Required: true},
+    },
+}
 
-    // Verifica que los parámetros -size y -path hayan sido proporcionados
-    if cmd.size == 0 {
-        return "", errors.New("faltan parámetros requeridos: -size")
-    }
-    if cmd.path == "" {
-        return "", errors.New("faltan parámetros requeridos: -path")
+func ParseMkdisk(tokens []string) (string, error) {
+    // Validar los parámetros contra el esquema del comando
+    params, err := mkdiskSchema.Parse(tokens)
+    if err != nil {
+        return "", err
     }
 
-    // Si no se proporcionó la unidad, se establece por defecto a "M"
-    if cmd.unit == "" {
-        cmd.unit = "M"
+    cmd := &MKDISK{
+        size: params.Int("size"),
+        unit: params.String("unit"),
+        fit:  params.String("fit"),
+        path: params.String("path"),
     }
 
-    // Si no se proporcionó el ajuste, se establece por defecto a "FF"
-    if cmd.fit == "" {
-        cmd.fit = "FF"
+    // Verifica que el tamaño sea positivo
+    if cmd.size <= 0 {
+        return "", errors.New("el tamaño debe ser un número entero positivo")
     }
 
     // Crear el disco con los parámetros proporcionados
-    err := commandMkdisk(cmd)
+    err = commandMkdisk(cmd)
     if err != nil {
         return "", err
     }
@@ -0,0 +1,155 @@
+package commands
+
+import (
+	stores "backend/stores"
+	structures "backend/structures"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// MKFILE estructura que representa el comando mkfile con sus parámetros
+type MKFILE struct {
+	path string // Path del archivo
+	r    bool   // Opción -r (crea carpetas padres si no existen)
+	size int    // Tamaño del archivo en bytes
+	cont string // Ruta de un archivo de la computadora con el contenido
+}
+
+/*
+   mkfile -size=15 -path=/home/user/docs/a.txt -r
+   mkfile -path=/home/user/docs/b.txt -cont=/home/Documents/b.txt
+*/
+
+// Parámetros aceptados por mkfile
+var mkfileSchema = Schema{
+	Command: "mkfile",
+	Params: []Param{
+		{Name: "path", Required: true},
+		{Name: "r", Type: ParamFlag},
+		{Name: "size", Type: ParamInt},
+		{Name: "cont"},
+	},
+}
+
+func ParseMkfile(tokens []string) (string, error) {
+	// Validar los parámetros contra el esquema del comando
+	params, err := mkfileSchema.Parse(tokens)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := &MKFILE{
+		path: params.String("path"),
+		r:    params.Flag("r"),
+		size: params.Int("size"),
+		cont: params.String("cont"),
+	}
+
+	// Verifica que el tamaño no sea negativo
+	if cmd.size < 0 {
+		return "", errors.New("el tamaño no puede ser negativo")
+	}
+
+	// Ejecutar el comando mkfile con los parámetros proporcionados
+	err = commandMkfile(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("MKFILE: Archivo %s creado correctamente.", cmd.path), nil
+}
+
+func commandMkfile(mkfile *MKFILE) error {
+	// Obtener el id de la partición montada que está logueada
+	if !stores.Auth.IsAuthenticated() {
+		return errors.New("no se ha iniciado sesión en ninguna partición")
+	}
+	partitionID := stores.Auth.GetPartitionID()
+
+	// Obtener la partición montada
+	partitionSuperblock, mountedPartition, partitionPath, err := stores.GetMountedPartitionSuperblock(partitionID)
+	if err != nil {
+		return fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+
+	// Obtener el contenido del archivo, sin superar el tamaño máximo que admite un inodo
+	maxSize := int64(structures.MaxFileBlocks) * int64(partitionSuperblock.S_block_size)
+	content, err := getMkfileContent(mkfile, maxSize)
+	if err != nil {
+		return err
+	}
+
+	// Crear el archivo
+	err = createFile(mkfile, content, partitionSuperblock, partitionPath, mountedPartition)
+	if err != nil {
+		err = fmt.Errorf("error al crear el archivo: %w", err)
+	}
+
+	return err
+}
+
+// getMkfileContent obtiene el contenido del archivo: el de -cont si se indicó o los dígitos del 0 al 9 hasta completar -size.
+// El tamaño se valida contra maxSize antes de leer o generar el contenido
+func getMkfileContent(mkfile *MKFILE, maxSize int64) (string, error) {
+	if mkfile.cont != "" {
+		info, err := os.Stat(mkfile.cont)
+		if err != nil {
+			return "", fmt.Errorf("no se pudo leer el archivo %s: %w", mkfile.cont, err)
+		}
+		if info.Size() > maxSize {
+			return "", fmt.Errorf("el archivo %s excede el tamaño máximo soportado (%d bytes)", mkfile.cont, maxSize)
+		}
+
+		data, err := os.ReadFile(mkfile.cont)
+		if err != nil {
+			return "", fmt.Errorf("no se pudo leer el archivo %s: %w", mkfile.cont, err)
+		}
+		return string(data), nil
+	}
+
+	if int64(mkfile.size) > maxSize {
+		return "", fmt.Errorf("el tamaño excede el máximo soportado (%d bytes)", maxSize)
+	}
+
+	var content strings.Builder
+	for i := 0; i < mkfile.size; i++ {
+		content.WriteByte(byte('0' + i%10))
+	}
+	return content.String(), nil
+}
+
+func createFile(mkfile *MKFILE, content string, sb *structures.SuperBlock, partitionPath string, mountedPartition *structures.Partition) error {
+	fmt.Println("\nCreando archivo:", mkfile.path)
+
+	// El archivo pertenece al usuario logueado
+	uid, gid := stores.Auth.GetUserIDs()
+
+	// Crear el archivo segun el path proporcionado
+	err := sb.CreateFile(partitionPath, mkfile.path, content, mkfile.r, uid, gid)
+	if err != nil {
+		// Guardar el superbloque aunque falle, ya que el inodo y los bloques liberados cambian sus contadores
+		serializeErr := sb.Serialize(partitionPath, int64(mountedPartition.Part_start))
+		if serializeErr != nil {
+			return fmt.Errorf("%w (error al serializar el superbloque: %v)", err, serializeErr)
+		}
+		return err
+	}
+
+	// Registrar la operación en el journaling si la partición es EXT3
+	if sb.S_filesystem_type == 3 {
+		err = sb.AddJournal(partitionPath, "mkfile", mkfile.path, content)
+		if err != nil {
+			return fmt.Errorf("error al registrar el journaling: %w", err)
+		}
+	}
+
+	// Serializar el superbloque
+	err = sb.Serialize(partitionPath, int64(mountedPartition.Part_start))
+	if err != nil {
+		return fmt.Errorf("error al serializar el superbloque: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMkfileContentChecksSizeFirst(t *testing.T) {
+	// Un -size enorme se rechaza sin generar el contenido
+	if _, err := getMkfileContent(&MKFILE{size: 2000000000}, 1024); err == nil {
+		t.Fatal("se esperaba un error por exceder el tamaño máximo")
+	}
+
+	content, err := getMkfileContent(&MKFILE{size: 12}, 1024)
+	if err != nil || content != "012345678901" {
+		t.Fatalf("contenido = %q, %v", content, err)
+	}
+
+	// Un archivo de -cont más grande que el máximo se rechaza sin leerlo
+	cont := filepath.Join(t.TempDir(), "grande.txt")
+	if err := os.WriteFile(cont, make([]byte, 2048), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getMkfileContent(&MKFILE{cont: cont}, 1024); err == nil {
+		t.Fatal("se esperaba un error por exceder el tamaño máximo con -cont")
+	}
+	if _, err := getMkfileContent(&MKFILE{cont: cont}, 4096); err != nil {
+		t.Fatalf("-cont dentro del máximo: %v", err)
+	}
+}
\ No newline at end of file
@@ -3,12 +3,10 @@ package commands
 import (
 	stores "backend/stores"
 	structures "backend/structures"
+	utils "backend/utils"
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"math"
-	"regexp"
-	"strings"
 	"time"
 )
 
@@ -25,83 +23,31 @@ type MKFS struct {
    mkfs -id=vd3
 */
 
-func ParseMkfs(tokens []string) (string, error) {
-	cmd := &MKFS{} // Crea una nueva instancia de MKFS
-
-	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
-	args := strings.Join(tokens, " ")
-	// Expresión regular para encontrar los parámetros del comando mkfs
-	re := regexp.MustCompile(`-id=[^\s]+|-type=[^\s]+|-fs=[23]fs`)
-	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
-	matches := re.FindAllString(args, -1)
-
-	// Verificar que todos los tokens fueron reconocidos por la expresión regular
-	if len(matches) != len(tokens) {
-		// Identificar el parámetro inválido
-		for _, token := range tokens {
-			if !re.MatchString(token) {
-				return "", fmt.Errorf("parámetro inválido: %s", token)
-			}
-		}
-	}
-
-	// Itera sobre cada coincidencia encontrada
-	for _, match := range matches {
-		// Divide cada parte en clave y valor usando "=" como delimitador
-		kv := strings.SplitN(match, "=", 2)
-		if len(kv) != 2 {
-			return "", fmt.Errorf("formato de parámetro inválido: %s", match)
-		}
-		key, value := strings.ToLower(kv[0]), kv[1]
-
-		// Remove quotes from value if present
-		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
-			value = strings.Trim(value, "\"")
-		}
-
-		// Switch para manejar diferentes parámetros
-		switch key {
-		case "-id":
-			// Verifica que el id no esté vacío
-			if value == "" {
-				return "", errors.New("el id no puede estar vacío")
-			}
-			cmd.id = value
-		case "-type":
-			// Verifica que el tipo sea "full"
-			if value != "full" {
-				return "", errors.New("el tipo debe ser full")
-			}
-			cmd.typ = value
-		case "-fs":
-			// Verifica que el sistema de archivos sea "2fs" o "3fs"
-			if value != "2fs" && value != "3fs" {
-				return "", errors.New("el sistema de archivos debe ser 2fs o 3fs")
-			}
-			cmd.fs = value
-		default:
-			// Si el parámetro no es reconocido, devuelve un error
-			return "", fmt.Errorf("parámetro desconocido: %s", key)
-		}
-	}
-
-	// Verifica que el parámetro -id haya sido proporcionado
-	if cmd.id == "" {
-		return "", errors.New("faltan parámetros requeridos: -id")
-	}
+// Parámetros aceptados por mkfs
+var mkfsSchema = Schema{
+	Command: "mkfs",
+	Params: []Param{
+		{Name: "id", Required: true},
+		{Name: "type", Allowed: []string{"full"}, Default: "full"},
+		{Name: "fs", Allowed: []string{"2fs", "3fs"}, Default: "2fs"},
+	},
+}
 
-	// Si no se proporcionó el tipo, se establece por defecto a "full"
-	if cmd.typ == "" {
-		cmd.typ = "full"
+func ParseMkfs(tokens []string) (string, error) {
+	// Validar los parámetros contra el esquema del comando
+	params, err := mkfsSchema.Parse(tokens)
+	if err != nil {
+		return "", err
 	}
 
-	// Si no se proporcionó el sistema de archivos, se establece por defecto a "2fs"
-	if cmd.fs == "" {
-		cmd.fs = "2fs"
+	cmd := &MKFS{
+		id:  params.String("id"),
+		typ: params.String("type"),
+		fs:  params.String("fs"),
 	}
 
 	// Aquí se puede agregar la lógica para ejecutar el comando mkfs con los parámetros proporcionados
-	err := commandMkfs(cmd)
+	err = commandMkfs(cmd)
 	if err != nil {
 		return "", err
 	}
@@ -129,6 +75,12 @@ func commandMkfs(mkfs *MKFS) error {
 
 	fmt.Printf("Valor de N: %d\n", n)
 
+	// El formateo completo limpia la partición, incluyendo el journaling anterior
+	err = utils.WriteZeros(partitionPath, int64(mountedPartition.Part_start), int64(mountedPartition.Part_size))
+	if err != nil {
+		return err
+	}
+
 	// Inicializar un nuevo superbloque
 	superBlock := createSuperBlock(mountedPartition, n, mkfs.fs)
 
@@ -141,7 +93,7 @@ func commandMkfs(mkfs *MKFS) error {
 	// Validar que sistema de archivos es
 	if superBlock.S_filesystem_type == 3 {
 		// Crear archivo users.txt ext3
-		err = superBlock.CreateUsersFileExt3(partitionPath, int64(mountedPartition.Part_start+int32(binary.Size(structures.SuperBlock{}))))
+		err = superBlock.CreateUsersFileExt3(partitionPath)
 		if err != nil {
 			return err
 		}
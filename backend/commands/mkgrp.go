@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"fmt"
+)
+
+// MKGRP estructura que representa el comando mkgrp con sus parámetros
+type MKGRP struct {
+	name string // Nombre del grupo
+}
+
+/*
+   mkgrp -name=usuarios
+   mkgrp -name="grupo 1"
+*/
+
+// Parámetros aceptados por mkgrp
+var mkgrpSchema = Schema{
+	Command: "mkgrp",
+	Params: []Param{
+		{Name: "name", Required: true},
+	},
+}
+
+func ParseMkgrp(tokens []string) (string, error) {
+	// Validar los parámetros contra el esquema del comando
+	params, err := mkgrpSchema.Parse(tokens)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := &MKGRP{
+		name: params.String("name"),
+	}
+
+	// Ejecutar el comando mkgrp
+	id, err := commandMkgrp(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("MKGRP: Grupo creado exitosamente\n"+
+		"-> Nombre: %s\n"+
+		"-> ID: %d", cmd.name, id), nil
+}
+
+func commandMkgrp(mkgrp *MKGRP) (int32, error) {
+	// Solo el usuario root puede crear grupos
+	err := requireRoot()
+	if err != nil {
+		return 0, err
+	}
+
+	err = validateUsersField("grupo", mkgrp.name)
+	if err != nil {
+		return 0, err
+	}
+
+	// Leer users.txt
+	users, err := loadUsersFile()
+	if err != nil {
+		return 0, err
+	}
+
+	// Verificar que el grupo no exista
+	if users.FindGroup(mkgrp.name) != nil {
+		return 0, fmt.Errorf("el grupo %s ya existe", mkgrp.name)
+	}
+
+	// Agregar el grupo con el siguiente ID
+	group := users.AddGroup(mkgrp.name)
+
+	return group.ID, users.save("mkgrp", mkgrp.name)
+}
\ No newline at end of file
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+)
+
+// MKUSR estructura que representa el comando mkusr con sus parámetros
+type MKUSR struct {
+	user string // Nombre del usuario
+	pass string // Contraseña del usuario
+	grp  string // Grupo al que pertenece el usuario
+}
+
+/*
+   mkusr -user=user1 -pass=usuario -grp=usuarios
+   mkusr -user="user 2" -pass=abc -grp=usuarios
+*/
+
+// Parámetros aceptados por mkusr
+var mkusrSchema = Schema{
+	Command: "mkusr",
+	Params: []Param{
+		{Name: "user", Required: true},
+		{Name: "pass", Required: true},
+		{Name: "grp", Required: true},
+	},
+}
+
+func ParseMkusr(tokens []string) (string, error) {
+	// Validar los parámetros contra el esquema del comando
+	params, err := mkusrSchema.Parse(tokens)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := &MKUSR{
+		user: params.String("user"),
+		pass: params.String("pass"),
+		grp:  params.String("grp"),
+	}
+
+	// Ejecutar el comando mkusr
+	id, err := commandMkusr(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("MKUSR: Usuario creado exitosamente\n"+
+		"-> Usuario: %s\n"+
+		"-> Grupo: %s\n"+
+		"-> ID: %d", cmd.user, cmd.grp, id), nil
+}
+
+func commandMkusr(mkusr *MKUSR) (int32, error) {
+	// Solo el usuario root puede crear usuarios
+	err := requireRoot()
+	if err != nil {
+		return 0, err
+	}
+
+	// Validar los campos que se guardan en users.txt
+	err = validateUsersField("usuario", mkusr.user)
+	if err != nil {
+		return 0, err
+	}
+	err = validateUsersField("contraseña", mkusr.pass)
+	if err != nil {
+		return 0, err
+	}
+
+	// Leer users.txt
+	users, err := loadUsersFile()
+	if err != nil {
+		return 0, err
+	}
+
+	// El grupo debe existir y el usuario no
+	if users.FindGroup(mkusr.grp) == nil {
+		return 0, fmt.Errorf("el grupo %s no existe", mkusr.grp)
+	}
+	if users.FindUser(mkusr.user) != nil {
+		return 0, fmt.Errorf("el usuario %s ya existe", mkusr.user)
+	}
+
+	// Agregar el usuario con el siguiente ID
+	user, err := users.AddUser(mkusr.grp, mkusr.user, mkusr.pass)
+	if err != nil {
+		return 0, fmt.Errorf("error al cifrar la contraseña: %w", err)
+	}
+
+	return user.ID, users.save("mkusr", mkusr.user)
+}
\ No newline at end of file
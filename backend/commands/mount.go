@@ -3,13 +3,10 @@ package commands
 import (
 	stores "backend/stores"
 	structures "backend/structures"
-	utils "backend/utils"
 	"errors" // Paquete para manejar errores y crear nuevos errores con mensajes personalizados
 	"fmt"    // Paquete para formatear cadenas y realizar operaciones de entrada/salida
-	"regexp" // Paquete para trabajar con expresiones regulares, útil para encontrar y manipular patrones en cadenas
 
 	// Paquete para convertir cadenas a otros tipos de datos, como enteros
-	"strings" // Paquete para manipular cadenas, como unir, dividir, y modificar contenido de cadenas
 )
 
 // MOUNT estructura que representa el comando mount con sus parámetros
@@ -24,57 +21,26 @@ type MOUNT struct {
 	mount -path=/home/Disco3.mia -name=Part2 #id=343a
 */
 
+// Parámetros aceptados por mount
+var mountSchema = Schema{
+	Command: "mount",
+	Params: []Param{
+		{Name: "path", Required: true},
+		{Name: "name", Required: true},
+	},
+}
+
 // CommandMount parsea el comando mount y devuelve una instancia de MOUNT
 func ParseMount(tokens []string) (string, error) {
-	cmd := &MOUNT{} // Crea una nueva instancia de MOUNT
-
-	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
-	args := strings.Join(tokens, " ")
-	// Expresión regular para encontrar los parámetros del comando mount
-	re := regexp.MustCompile(`-path="[^"]+"|-path=[^\s]+|-name="[^"]+"|-name=[^\s]+`)
-	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
-	matches := re.FindAllString(args, -1)
-
-	// Itera sobre cada coincidencia encontrada
-	for _, match := range matches {
-		// Divide cada parte en clave y valor usando "=" como delimitador
-		kv := strings.SplitN(match, "=", 2)
-		if len(kv) != 2 {
-			return "", fmt.Errorf("formato de parámetro inválido: %s", match)
-		}
-		key, value := strings.ToLower(kv[0]), kv[1]
-
-		// Remove quotes from value if present
-		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
-			value = strings.Trim(value, "\"")
-		}
-
-		// Switch para manejar diferentes parámetros
-		switch key {
-		case "-path":
-			// Verifica que el path no esté vacío
-			if value == "" {
-				return "", errors.New("el path no puede estar vacío")
-			}
-			cmd.path = value
-		case "-name":
-			// Verifica que el nombre no esté vacío
-			if value == "" {
-				return "", errors.New("el nombre no puede estar vacío")
-			}
-			cmd.name = value
-		default:
-			// Si el parámetro no es reconocido, devuelve un error
-			return "", fmt.Errorf("parámetro desconocido: %s", key)
-		}
+	// Validar los parámetros contra el esquema del comando
+	params, err := mountSchema.Parse(tokens)
+	if err != nil {
+		return "", err
 	}
 
-	// Verifica que los parámetros -path y -name hayan sido proporcionados
-	if cmd.path == "" {
-		return "", errors.New("faltan parámetros requeridos: -path")
-	}
-	if cmd.name == "" {
-		return "", errors.New("faltan parámetros requeridos: -name")
+	cmd := &MOUNT{
+		path: params.String("path"),
+		name: params.String("name"),
 	}
 
 	// Montamos la partición
@@ -103,26 +69,58 @@ func commandMount(mount *MOUNT) (string, error) {
 	}
 
 	// Buscar la partición con el nombre especificado
-	partition, indexPartition := mbr.GetPartitionByName(mount.name)
+	partition, indexPartition := mbr.GetPartitionByName(mount.name, mount.path)
 	if partition == nil {
 		fmt.Println("Error: la partición no existe")
 		return "", errors.New("la partición no existe")
 	}
 
+	// Las particiones extendidas no se pueden montar, solo sus particiones lógicas
+	if partition.Part_type[0] == 'E' {
+		return "", errors.New("no se puede montar una partición extendida")
+	}
+
+	// Verificar que la partición no esté montada
+	if stores.IsPartitionMounted(mount.path, &mbr, partition) {
+		return "", errors.New("la partición ya está montada")
+	}
+
 	/* SOLO PARA VERIFICACIÓN */
 	// Print para verificar que la partición se encontró correctamente
 	fmt.Println("\nPartición disponible:")
 	partition.PrintPartition()
 
+	// Si la partición quedó montada antes de un reinicio se conserva el id guardado en el MBR o en su EBR
+	if idPartition, ok := stores.StoredPartitionID(mount.path, partition); ok {
+		err = stores.AddMountedPartition(idPartition, mount.path, mount.name, mountType(partition))
+		if err != nil {
+			return "", err
+		}
+		return idPartition, nil
+	}
+
 	// Generar un id único para la partición
-	idPartition, partitionCorrelative, err := generatePartitionID(mount)
+	idPartition, partitionCorrelative, err := stores.NextPartitionID(mount.path, &mbr)
 	if err != nil {
 		fmt.Println("Error generando el id de partición:", err)
 		return "", err
 	}
 
-	//  Guardar la partición montada en la lista de montajes globales
-	stores.MountedPartitions[idPartition] = mount.path
+	// Las particiones lógicas se marcan como montadas en su EBR, que también guarda su id
+	if partition.Part_type[0] == 'L' {
+		err = mountLogicalPartition(&mbr, mount, partitionCorrelative, idPartition)
+		if err != nil {
+			return "", err
+		}
+
+		//  Guardar la partición montada en la lista de montajes globales
+		err = stores.AddMountedPartition(idPartition, mount.path, mount.name, "L")
+		if err != nil {
+			return "", err
+		}
+
+		return idPartition, nil
+	}
 
 	// Modificamos la partición para indicar que está montada
 	partition.MountPartition(partitionCorrelative, idPartition)
@@ -142,19 +140,44 @@ func commandMount(mount *MOUNT) (string, error) {
 		return "", err
 	}
 
+	//  Guardar la partición montada en la lista de montajes globales
+	err = stores.AddMountedPartition(idPartition, mount.path, mount.name, "P")
+	if err != nil {
+		return "", err
+	}
+
 	return idPartition, nil
 }
 
-func generatePartitionID(mount *MOUNT) (string, int, error) {
-	// Asignar una letra a la partición y obtener el índice
-	letter, partitionCorrelative, err := utils.GetLetterAndPartitionCorrelative(mount.path)
+// mountType devuelve el tipo con el que se registra la partición en la tabla de montajes
+func mountType(partition *structures.Partition) string {
+	if partition.Part_type[0] == 'L' {
+		return "L"
+	}
+	return "P"
+}
+
+func mountLogicalPartition(mbr *structures.MBR, mount *MOUNT, correlative int, id string) error {
+	// Buscar el EBR de la partición lógica
+	ebr, err := mbr.GetLogicalPartitionByName(mount.name, mount.path)
 	if err != nil {
-		fmt.Println("Error obteniendo la letra:", err)
-		return "", 0, err
+		return err
 	}
 
-	// Crear id de partición
-	idPartition := fmt.Sprintf("%s%d%s", stores.Carnet, partitionCorrelative, letter)
+	// Marcar el EBR como montado y guardar el id en el disco
+	ebr.Mount(correlative, id)
+
+	/* SOLO PARA VERIFICACIÓN */
+	// Print para verificar que el EBR se haya montado correctamente
+	fmt.Println("\nEBR montado (modificado):")
+	ebr.PrintEBR()
+
+	// Serializar el EBR en su posición dentro de la partición extendida
+	err = ebr.Serialize(mount.path, int64(ebr.Part_start))
+	if err != nil {
+		fmt.Println("Error serializando el EBR:", err)
+		return err
+	}
 
-	return idPartition, partitionCorrelative, nil
+	return nil
 }
\ No newline at end of file
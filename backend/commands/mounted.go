@@ -2,42 +2,142 @@ package commands
 
 import (
 	"backend/stores"
+	structures "backend/structures"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"text/tabwriter"
+	"time"
 )
 
-// ParseMount parsea el comando mount y devuelve una instancia de MOUNT
+// MountedInfo representa la información de una partición montada que se muestra con el comando mounted
+type MountedInfo struct {
+	ID         string `json:"id"`
+	Path       string `json:"path"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Size       int32  `json:"size"`
+	FileSystem string `json:"filesystem"`
+	FreeInodes *int32 `json:"free_inodes"`
+	FreeBlocks *int32 `json:"free_blocks"`
+	MountTime  string `json:"mount_time"`
+	Error      string `json:"error,omitempty"`
+}
+
+/*
+	mounted
+	mounted -json
+*/
+
+// Parámetros aceptados por mounted
+var mountedSchema = Schema{
+	Command: "mounted",
+	Params: []Param{
+		{Name: "json", Type: ParamFlag},
+	},
+}
+
+// ParseMounted parsea el comando mounted y devuelve la tabla de particiones montadas
 func ParseMounted(tokens []string) (string, error) {
-	// Verifica que no se hayan pasado parámetros
-	if len(tokens) > 0 {
-		return "", fmt.Errorf("el comando 'mounted' no acepta parámetros: %s", strings.Join(tokens, " "))
+	// Validar los parámetros contra el esquema del comando
+	params, err := mountedSchema.Parse(tokens)
+	if err != nil {
+		return "", err
 	}
 
 	// Ejecuta el comando mounted
-	return commandMounted()
+	return commandMounted(params.Flag("json"))
 }
 
 // Ejecuta el comando mounted
-func commandMounted() (string, error) {
+func commandMounted(asJSON bool) (string, error) {
+    // Ordenar los ids para que la salida sea estable
+    ids := make([]string, 0, len(stores.MountedPartitions))
+    for id := range stores.MountedPartitions {
+        ids = append(ids, id)
+    }
+    sort.Strings(ids)
+
+    // Obtener la información de cada partición montada
+    infos := make([]MountedInfo, 0, len(ids))
+    for _, id := range ids {
+        infos = append(infos, getMountedInfo(id))
+    }
+
+    if asJSON {
+        data, err := json.MarshalIndent(infos, "", "  ")
+        if err != nil {
+            return "", fmt.Errorf("error al generar el JSON: %w", err)
+        }
+        return string(data), nil
+    }
+
     // Verifica si hay particiones montadas
-    if len(stores.MountedPartitions) == 0 {
+    if len(infos) == 0 {
         return "No hay particiones montadas.", nil
     }
 
-    // Crea un string para mostrar las particiones montadas
+    // Crea una tabla para mostrar las particiones montadas
     var result strings.Builder
     result.WriteString("Particiones montadas:\n")
-    for id := range stores.MountedPartitions {
-        // Obtener la partición montada y su ruta
-        partition, path, err := stores.GetMountedPartition(id)
-        if err != nil {
-            result.WriteString(fmt.Sprintf("ID: %s, Error: %s\n", id, err.Error()))
+    w := tabwriter.NewWriter(&result, 0, 0, 2, ' ', 0)
+    fmt.Fprintln(w, "ID\tPATH\tNOMBRE\tTIPO\tTAMAÑO\tSISTEMA\tINODOS LIBRES\tBLOQUES LIBRES\tMONTADA")
+    for _, info := range infos {
+        // Si no se pudo leer la partición, se muestra el error en la fila
+        if info.Error != "" {
+            fmt.Fprintf(w, "%s\t%s\t%s\t%s\tError: %s\n", info.ID, info.Path, info.Name, info.Type, info.Error)
             continue
         }
 
-        // Agregar la información de la partición al resultado
-        result.WriteString(fmt.Sprintf("ID: %s, Path: %s, Nombre: %s\n", id, path, partition.Part_name))
+        fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+            info.ID, info.Path, info.Name, info.Type, info.Size, info.FileSystem,
+            formatCount(info.FreeInodes), formatCount(info.FreeBlocks), info.MountTime)
     }
+    w.Flush()
 
     return result.String(), nil
+}
+
+// getMountedInfo obtiene la información de la partición montada con el id especificado
+func getMountedInfo(id string) MountedInfo {
+    mounted := stores.MountedPartitions[id]
+    info := MountedInfo{ID: id, Path: mounted.Path, Name: mounted.Name, Type: mounted.Type, FileSystem: "sin formato", MountTime: "-"}
+
+    if mounted.MountTime > 0 {
+        info.MountTime = time.Unix(mounted.MountTime, 0).Format(time.RFC3339)
+    }
+
+    // Obtener la partición montada y su ruta
+    partition, path, err := stores.GetMountedPartition(id)
+    if err != nil {
+        info.Error = err.Error()
+        return info
+    }
+    info.Size = partition.Part_size
+
+    // Leer el superbloque para conocer el sistema de archivos y el espacio libre
+    var sb structures.SuperBlock
+    err = sb.Deserialize(path, int64(partition.Part_start))
+    if err != nil || sb.S_magic != 0xEF53 {
+        return info
+    }
+
+    if sb.S_filesystem_type == 3 {
+        info.FileSystem = "EXT3"
+    } else {
+        info.FileSystem = "EXT2"
+    }
+    info.FreeInodes = &sb.S_free_inodes_count
+    info.FreeBlocks = &sb.S_free_blocks_count
+
+    return info
+}
+
+// formatCount muestra un contador o un guion si la partición no tiene formato
+func formatCount(count *int32) string {
+    if count == nil {
+        return "-"
+    }
+    return fmt.Sprintf("%d", *count)
 }
\ No newline at end of file
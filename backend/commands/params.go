@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParamType tipo de valor que recibe un parámetro
+type ParamType int
+
+const (
+	ParamString ParamType = iota // Texto (ej. -path=/home/Disco1.mia)
+	ParamInt                     // Número entero, puede ser negativo (ej. -add=-500)
+	ParamFlag                    // Sin valor (ej. -p, -r)
+)
+
+// Param describe un parámetro que acepta un comando
+type Param struct {
+	Name     string    // Nombre sin guion y en minúsculas (ej. "path")
+	Type     ParamType // Tipo del valor
+	Required bool      // Si el parámetro es obligatorio
+	Default  string    // Valor por defecto cuando no se proporciona
+	Allowed  []string  // Valores permitidos sin distinguir mayúsculas, vacío si acepta cualquiera
+	Numbered bool      // Si el nombre lleva un número al final (ej. -file1, -file2)
+}
+
+// Schema describe todos los parámetros que acepta un comando
+type Schema struct {
+	Command string
+	Params  []Param
+}
+
+// Params contiene los valores ya validados de los parámetros de un comando
+type Params struct {
+	values   map[string]string
+	numbered map[string]map[int]string
+}
+
+// Parse valida los tokens de un comando contra el esquema y devuelve sus valores.
+// Los nombres de los parámetros no distinguen mayúsculas y los tokens ya vienen sin comillas
+func (s *Schema) Parse(tokens []string) (*Params, error) {
+	params := &Params{
+		values:   make(map[string]string),
+		numbered: make(map[string]map[int]string),
+	}
+
+	for _, token := range tokens {
+		// Separar el nombre y el valor usando el primer "="
+		name, value, hasValue := strings.Cut(token, "=")
+		if !strings.HasPrefix(name, "-") || len(name) == 1 {
+			return nil, fmt.Errorf("parámetro inválido: %s", token)
+		}
+		name = strings.ToLower(name[1:])
+
+		// Buscar el parámetro en el esquema
+		param, number, ok := s.lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("parámetro desconocido para %s: -%s", s.Command, name)
+		}
+
+		// Verificar que no se repita
+		_, exists := params.values[name]
+		if param.Numbered {
+			_, exists = params.numbered[param.Name][number]
+		}
+		if exists {
+			return nil, fmt.Errorf("parámetro repetido: -%s", name)
+		}
+
+		// Validar el valor según el tipo del parámetro
+		value, err := param.validate(name, value, hasValue)
+		if err != nil {
+			return nil, err
+		}
+
+		params.values[name] = value
+		if param.Numbered {
+			if params.numbered[param.Name] == nil {
+				params.numbered[param.Name] = make(map[int]string)
+			}
+			params.numbered[param.Name][number] = value
+		}
+	}
+
+	// Verificar los parámetros obligatorios y asignar los valores por defecto
+	var missing []string
+	for _, param := range s.Params {
+		if param.Numbered {
+			if param.Required && len(params.numbered[param.Name]) == 0 {
+				missing = append(missing, "-"+param.Name+"1")
+			}
+			continue
+		}
+		if _, exists := params.values[param.Name]; exists {
+			continue
+		}
+		if param.Required {
+			missing = append(missing, "-"+param.Name)
+		} else if param.Default != "" {
+			params.values[param.Name] = param.Default
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("faltan parámetros requeridos: %s", strings.Join(missing, ", "))
+	}
+
+	return params, nil
+}
+
+// lookup busca un parámetro por nombre; para los numerados devuelve también su número
+func (s *Schema) lookup(name string) (*Param, int, bool) {
+	for i := range s.Params {
+		param := &s.Params[i]
+		if !param.Numbered {
+			if param.Name == name {
+				return param, 0, true
+			}
+			continue
+		}
+
+		// El número debe ser un entero positivo (ej. file1)
+		suffix, found := strings.CutPrefix(name, param.Name)
+		if !found {
+			continue
+		}
+		number, err := strconv.Atoi(suffix)
+		if err == nil && number >= 1 && !strings.HasPrefix(suffix, "+") {
+			return param, number, true
+		}
+	}
+	return nil, 0, false
+}
+
+// validate verifica el valor de un parámetro y lo devuelve normalizado
+func (p *Param) validate(name string, value string, hasValue bool) (string, error) {
+	if p.Type == ParamFlag {
+		if hasValue {
+			return "", fmt.Errorf("el parámetro -%s no recibe valor", name)
+		}
+		return "true", nil
+	}
+
+	if !hasValue {
+		return "", fmt.Errorf("el parámetro -%s requiere un valor", name)
+	}
+	if value == "" {
+		return "", fmt.Errorf("el parámetro -%s no puede estar vacío", name)
+	}
+
+	if p.Type == ParamInt {
+		if _, err := strconv.Atoi(value); err != nil {
+			return "", fmt.Errorf("el parámetro -%s debe ser un número entero: %s", name, value)
+		}
+	}
+
+	// Los valores permitidos se guardan con la forma declarada en el esquema
+	if len(p.Allowed) > 0 {
+		for _, allowed := range p.Allowed {
+			if strings.EqualFold(value, allowed) {
+				return allowed, nil
+			}
+		}
+		return "", fmt.Errorf("valor inválido para -%s: %s (valores permitidos: %s)", name, value, strings.Join(p.Allowed, ", "))
+	}
+
+	return value, nil
+}
+
+// String devuelve el valor de un parámetro, o "" si no se proporcionó y no tiene valor por defecto
+func (p *Params) String(name string) string {
+	return p.values[name]
+}
+
+// Int devuelve el valor entero de un parámetro, o 0 si no se proporcionó
+func (p *Params) Int(name string) int {
+	value, _ := strconv.Atoi(p.values[name])
+	return value
+}
+
+// Flag indica si se proporcionó un parámetro sin valor
+func (p *Params) Flag(name string) bool {
+	return p.values[name] == "true"
+}
+
+// Has indica si el parámetro se proporcionó o tiene valor por defecto
+func (p *Params) Has(name string) bool {
+	_, exists := p.values[name]
+	return exists
+}
+
+// Numbered devuelve los valores de un parámetro numerado ordenados por su número
+func (p *Params) Numbered(name string) []string {
+	values := p.numbered[name]
+	numbers := make([]int, 0, len(values))
+	for number := range values {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	ordered := make([]string, 0, len(numbers))
+	for _, number := range numbers {
+		ordered = append(ordered, values[number])
+	}
+	return ordered
+}
\ No newline at end of file
@@ -3,10 +3,7 @@ package commands
 import (
 	reports "backend/reports"
 	stores "backend/stores"
-	"errors"
 	"fmt"
-	"regexp"
-	"strings"
 )
 
 // REP estructura que representa el comando rep con sus parámetros
@@ -17,67 +14,34 @@ type REP struct {
 	path_file_ls string // Ruta del archivo ls (opcional)
 }
 
+// Parámetros aceptados por rep
+var repSchema = Schema{
+	Command: "rep",
+	Params: []Param{
+		{Name: "id", Required: true},
+		{Name: "path", Required: true},
+		{Name: "name", Required: true, Allowed: []string{"mbr", "disk", "inode", "block", "bm_inode", "bm_block", "sb", "file", "ls"}},
+		{Name: "path_file_ls"},
+	},
+}
+
 // ParserRep parsea el comando rep y devuelve una instancia de REP
 func ParseRep(tokens []string) (string, error) {
-	cmd := &REP{} // Crea una nueva instancia de REP
-
-	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
-	args := strings.Join(tokens, " ")
-	// Expresión regular para encontrar los parámetros del comando rep
-	re := regexp.MustCompile(`-id=[^\s]+|-path="[^"]+"|-path=[^\s]+|-name=[^\s]+|-path_file_ls="[^"]+"|-path_file_ls=[^\s]+`)
-	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
-	matches := re.FindAllString(args, -1)
-
-	// Itera sobre cada coincidencia encontrada
-	for _, match := range matches {
-		// Divide cada parte en clave y valor usando "=" como delimitador
-		kv := strings.SplitN(match, "=", 2)
-		if len(kv) != 2 {
-			return "", fmt.Errorf("formato de parámetro inválido: %s", match)
-		}
-		key, value := strings.ToLower(kv[0]), kv[1]
-
-		// Remove quotes from value if present
-		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
-			value = strings.Trim(value, "\"")
-		}
-
-		// Switch para manejar diferentes parámetros
-		switch key {
-		case "-id":
-			// Verifica que el id no esté vacío
-			if value == "" {
-				return "", errors.New("el id no puede estar vacío")
-			}
-			cmd.id = value
-		case "-path":
-			// Verifica que el path no esté vacío
-			if value == "" {
-				return "", errors.New("el path no puede estar vacío")
-			}
-			cmd.path = value
-		case "-name":
-			// Verifica que el nombre sea uno de los valores permitidos
-			validNames := []string{"mbr", "disk", "inode", "block", "bm_inode", "bm_block", "sb", "file", "ls"}
-			if !contains(validNames, value) {
-				return "", errors.New("nombre inválido, debe ser uno de los siguientes: mbr, disk, inode, block, bm_inode, bm_block, sb, file, ls")
-			}
-			cmd.name = value
-		case "-path_file_ls":
-			cmd.path_file_ls = value
-		default:
-			// Si el parámetro no es reconocido, devuelve un error
-			return "", fmt.Errorf("parámetro desconocido: %s", key)
-		}
+	// Validar los parámetros contra el esquema del comando
+	params, err := repSchema.Parse(tokens)
+	if err != nil {
+		return "", err
 	}
 
-	// Verifica que los parámetros obligatorios hayan sido proporcionados
-	if cmd.id == "" || cmd.path == "" || cmd.name == "" {
-		return "", errors.New("faltan parámetros requeridos: -id, -path, -name")
+	cmd := &REP{
+		id:           params.String("id"),
+		path:         params.String("path"),
+		name:         params.String("name"),
+		path_file_ls: params.String("path_file_ls"),
 	}
 
 	// Aquí se puede agregar la lógica para ejecutar el comando rep con los parámetros proporcionados
-	err := commandRep(cmd)
+	err = commandRep(cmd)
 	if err != nil {
 		return "", err
 	}
@@ -97,16 +61,6 @@ func ParseRep(tokens []string) (string, error) {
 		}()), nil
 }
 
-// Función auxiliar para verificar si un valor está en una lista
-func contains(list []string, value string) bool {
-	for _, v := range list {
-		if v == value {
-			return true
-		}
-	}
-	return false
-}
-
 // Ejemplo de función commandRep (debe ser implementada)
 func commandRep(rep *REP) error {
 	// Obtener la partición montada
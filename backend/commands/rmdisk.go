@@ -4,67 +4,58 @@ import (
 	"errors"        // Paquete para manejar errores y crear nuevos errores con mensajes personalizados
 	"fmt"           // Paquete para formatear cadenas y realizar operaciones de entrada/salida
 	"os"            // Paquete para interactuar con el sistema operativo
-	"regexp"        // Paquete para trabajar con expresiones regulares, útil para encontrar y manipular patrones en cadenas
+	"sort"          // Paquete para ordenar los ids de las particiones montadas
 	"strings"       // Paquete para manipular cadenas, como unir, dividir, y modificar contenido de cadenas
+	"backend/stores"
+	structures "backend/structures"
 	"backend/utils"
 )
 
 // RMDISK estructura que representa el comando rmdisk con sus parámetros
 type RMDISK struct {
-	path string // Ruta del archivo del disco
+	path  string // Ruta del archivo del disco
+	force bool   // Desmontar las particiones del disco antes de eliminarlo
 }
-//
-// ParseRmdisk parsea el comando rmdisk y devuelve una instancia de RMDISK
-func ParseRmdisk(tokens []string) (string, error) {
-	cmd := &RMDISK{} // Crea una nueva instancia de RMDISK
-
-	// Unir tokens en una sola cadena y luego dividir por espacios, respetando las comillas
-	args := strings.Join(tokens, " ")
-	// Expresión regular para encontrar los parámetros del comando rmdisk
-	re := regexp.MustCompile(`-path="[^"]+"|-path=[^\s]+`)
-	// Encuentra todas las coincidencias de la expresión regular en la cadena de argumentos
-	matches := re.FindAllString(args, -1)
-
-	// Itera sobre cada coincidencia encontrada
-	for _, match := range matches {
-		// Divide cada parte en clave y valor usando "=" como delimitador
-		kv := strings.SplitN(match, "=", 2)
-		if len(kv) != 2 {
-			return "", fmt.Errorf("formato de parámetro inválido: %s", match)
-		}
-		key, value := strings.ToLower(kv[0]), kv[1]
 
-		// Remove quotes from value if present
-		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
-			value = strings.Trim(value, "\"")
-		}
+/*
+	rmdisk -path=/home/user/Disco1.mia
+	rmdisk -path=/home/user/Disco1.mia -force
+*/
+
+// Parámetros aceptados por rmdisk
+var rmdiskSchema = Schema{
+	Command: "rmdisk",
+	Params: []Param{
+		{Name: "path", Required: true},
+		{Name: "force", Type: ParamFlag},
+	},
+}
 
-		switch key {
-		case "-path":
-			cmd.path = value // Asigna el valor a la ruta del disco
+// ParseRmdisk parsea el comando rmdisk y devuelve una instancia de RMDISK
+func ParseRmdisk(tokens []string) (string, error) {
+	// Validar los parámetros contra el esquema del comando
+	params, err := rmdiskSchema.Parse(tokens)
+	if err != nil {
+		return "", err
+	}
 
-			// Verifica si la ruta es válida
-			if !strings.HasSuffix(cmd.path, ".mia") {
-				return "", errors.New("la ruta debe terminar con .mia")
-			}
+	cmd := &RMDISK{
+		path:  params.String("path"),
+		force: params.Flag("force"),
+	}
 
-			// Verifica si el disco existe
-			if !utils.FileExists(cmd.path) {
-				return "", errors.New("el disco no existe")
-			}
-			
-			break
-		default:
-			return "", fmt.Errorf("parámetro desconocido: %s", key)
-		}
+	// Verifica si la ruta es válida
+	if !strings.HasSuffix(cmd.path, ".mia") {
+		return "", errors.New("la ruta debe terminar con .mia")
 	}
 
-	if cmd.path == "" {
-		return "", errors.New("se requiere el parámetro -path")
+	// Verifica si el disco existe
+	if !utils.FileExists(cmd.path) {
+		return "", errors.New("el disco no existe")
 	}
 
 	// Ejecuta el comando rmdisk
-	err := cmd.Execute()
+	err = cmd.Execute()
 	if err != nil {
 		return "", fmt.Errorf("error al ejecutar el comando rmdisk: %v", err)
 	}
@@ -75,21 +66,52 @@ func ParseRmdisk(tokens []string) (string, error) {
 
 // Execute ejecuta el comando rmdisk
 func (cmd *RMDISK) Execute() error {
+	// Verificar que el archivo sea un disco antes de eliminarlo
+	var mbr structures.MBR
+	err := mbr.CheckDiskImage(cmd.path)
+	if err != nil {
+		return err
+	}
+
+	// Buscar las particiones montadas del disco
+	var mountedIDs []string
+	for id, mounted := range stores.MountedPartitions {
+		if mounted.Path == cmd.path {
+			mountedIDs = append(mountedIDs, id)
+		}
+	}
+	sort.Strings(mountedIDs)
+
+	if len(mountedIDs) > 0 {
+		// Sin -force no se elimina un disco con particiones montadas o con una sesión activa
+		if !cmd.force {
+			return fmt.Errorf("el disco tiene particiones montadas (%s), use -force para desmontarlas", strings.Join(mountedIDs, ", "))
+		}
+
+		// Desmontar cada partición; esto también cierra la sesión si el usuario está en ella
+		for _, id := range mountedIDs {
+			if commandUnmount(&UNMOUNT{id: id}) == nil {
+				continue
+			}
+
+			// Si no se pudo actualizar el disco, igual se limpia la sesión y la tabla de montajes
+			if stores.Auth.GetPartitionID() == id {
+				stores.Auth.Logout()
+			}
+			err = stores.RemoveMountedPartition(id)
+			if err != nil {
+				return fmt.Errorf("error al desmontar la partición %s: %v", id, err)
+			}
+		}
+	}
+
 	// Elimina el disco
-	err := os.Remove(cmd.path)
+	err = os.Remove(cmd.path)
 	if err != nil {
 		return fmt.Errorf("error al eliminar el disco: %v", err)
 	}
 
 	fmt.Printf("Disco eliminado: %s\n", cmd.path)
 
-	// Actualiza la lista de discos montados
-	/*for i, disk := range structures.MountedDisks {
-		if disk.Path == cmd.path {
-			structures.MountedDisks = append(structures.MountedDisks[:i], structures.MountedDisks[i+1:]...)
-			break
-		}
-	}*/
-
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RMGRP estructura que representa el comando rmgrp con sus parámetros
+type RMGRP struct {
+	name string // Nombre del grupo
+}
+
+/*
+   rmgrp -name=usuarios
+*/
+
+// Parámetros aceptados por rmgrp
+var rmgrpSchema = Schema{
+	Command: "rmgrp",
+	Params: []Param{
+		{Name: "name", Required: true},
+	},
+}
+
+func ParseRmgrp(tokens []string) (string, error) {
+	// Validar los parámetros contra el esquema del comando
+	params, err := rmgrpSchema.Parse(tokens)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := &RMGRP{
+		name: params.String("name"),
+	}
+
+	// Ejecutar el comando rmgrp
+	err = commandRmgrp(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("RMGRP: Grupo %s eliminado exitosamente", cmd.name), nil
+}
+
+func commandRmgrp(rmgrp *RMGRP) error {
+	// Solo el usuario root puede eliminar grupos
+	err := requireRoot()
+	if err != nil {
+		return err
+	}
+
+	// El grupo root no se puede eliminar
+	if rmgrp.name == "root" {
+		return errors.New("no se puede eliminar el grupo root")
+	}
+
+	// Leer users.txt
+	users, err := loadUsersFile()
+	if err != nil {
+		return err
+	}
+
+	// Buscar el grupo activo
+	group := users.FindGroup(rmgrp.name)
+	if group == nil {
+		return fmt.Errorf("el grupo %s no existe", rmgrp.name)
+	}
+
+	// No se puede eliminar un grupo que todavía tiene usuarios activos
+	if members := users.UsersInGroup(group.Name); len(members) > 0 {
+		names := make([]string, len(members))
+		for i, member := range members {
+			names[i] = member.Name
+		}
+		return fmt.Errorf("el grupo %s tiene usuarios activos (%s), elimínelos o cámbielos de grupo con chgrp", rmgrp.name, strings.Join(names, ", "))
+	}
+
+	// Eliminación lógica: el ID del grupo pasa a 0
+	group.ID = 0
+
+	return users.save("rmgrp", rmgrp.name)
+}
\ No newline at end of file
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRmgrpRejectsGroupWithActiveUsers(t *testing.T) {
+	id := newTestPartition(t)
+
+	commands := [][]string{
+		{"-user=root", "-pass=123", "-id=" + id},
+		{"-name=usuarios"},
+		{"-user=ana", "-pass=abc", "-grp=usuarios"},
+	}
+	parsers := []func([]string) (string, error){ParseLogin, ParseMkgrp, ParseMkusr}
+	for i, parse := range parsers {
+		if _, err := parse(commands[i]); err != nil {
+			t.Fatalf("%v: %v", commands[i], err)
+		}
+	}
+
+	_, err := ParseRmgrp([]string{"-name=usuarios"})
+	if err == nil || !strings.Contains(err.Error(), "ana") {
+		t.Fatalf("rmgrp con usuarios activos = %v; se esperaba un error que nombre a ana", err)
+	}
+
+	// Sin usuarios activos el grupo sí se puede eliminar
+	if _, err := ParseRmusr([]string{"-user=ana"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseRmgrp([]string{"-name=usuarios"}); err != nil {
+		t.Fatalf("rmgrp sin usuarios activos: %v", err)
+	}
+}
\ No newline at end of file
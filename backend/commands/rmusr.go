@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+)
+
+// RMUSR estructura que representa el comando rmusr con sus parámetros
+type RMUSR struct {
+	user string // Nombre del usuario
+}
+
+/*
+   rmusr -user=user1
+*/
+
+// Parámetros aceptados por rmusr
+var rmusrSchema = Schema{
+	Command: "rmusr",
+	Params: []Param{
+		{Name: "user", Required: true},
+	},
+}
+
+func ParseRmusr(tokens []string) (string, error) {
+	// Validar los parámetros contra el esquema del comando
+	params, err := rmusrSchema.Parse(tokens)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := &RMUSR{
+		user: params.String("user"),
+	}
+
+	// Ejecutar el comando rmusr
+	err = commandRmusr(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("RMUSR: Usuario %s eliminado exitosamente", cmd.user), nil
+}
+
+func commandRmusr(rmusr *RMUSR) error {
+	// Solo el usuario root puede eliminar usuarios
+	err := requireRoot()
+	if err != nil {
+		return err
+	}
+
+	// El usuario root no se puede eliminar
+	if rmusr.user == "root" {
+		return errors.New("no se puede eliminar el usuario root")
+	}
+
+	// Leer users.txt
+	users, err := loadUsersFile()
+	if err != nil {
+		return err
+	}
+
+	// Buscar el usuario activo
+	user := users.FindUser(rmusr.user)
+	if user == nil {
+		return fmt.Errorf("el usuario %s no existe", rmusr.user)
+	}
+
+	// Eliminación lógica: el ID del usuario pasa a 0
+	user.ID = 0
+
+	return users.save("rmusr", rmusr.user)
+}
\ No newline at end of file
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	stores "backend/stores"
+	structures "backend/structures"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UNMOUNT estructura que representa el comando unmount con sus parámetros
+type UNMOUNT struct {
+	id string // ID de la partición montada
+}
+
+/*
+	unmount -id=961A
+*/
+
+// Parámetros aceptados por unmount
+var unmountSchema = Schema{
+	Command: "unmount",
+	Params: []Param{
+		{Name: "id", Required: true},
+	},
+}
+
+// ParseUnmount parsea el comando unmount y desmonta la partición indicada
+func ParseUnmount(tokens []string) (string, error) {
+	// Validar los parámetros contra el esquema del comando
+	params, err := unmountSchema.Parse(tokens)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := &UNMOUNT{
+		id: params.String("id"),
+	}
+
+	// Desmontamos la partición
+	err = commandUnmount(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("UNMOUNT: Partición desmontada exitosamente\n"+
+		"-> ID: %s", cmd.id), nil
+}
+
+func commandUnmount(unmount *UNMOUNT) error {
+	// Obtener la partición montada
+	partition, partitionPath, err := stores.GetMountedPartition(unmount.id)
+	if err != nil {
+		return err
+	}
+
+	// Registrar la fecha de desmontaje en el superbloque si la partición está formateada
+	var sb structures.SuperBlock
+	err = sb.Deserialize(partitionPath, int64(partition.Part_start))
+	if err == nil && sb.S_magic == 0xEF53 {
+		sb.S_umtime = float32(time.Now().Unix())
+		err = sb.Serialize(partitionPath, int64(partition.Part_start))
+		if err != nil {
+			return fmt.Errorf("error al actualizar el superbloque: %w", err)
+		}
+	}
+
+	// Crear una instancia de MBR
+	var mbr structures.MBR
+
+	// Deserializar la estructura MBR desde un archivo binario
+	err = mbr.Deserialize(partitionPath)
+	if err != nil {
+		fmt.Println("Error deserializando el MBR:", err)
+		return err
+	}
+
+	if partition.Part_type[0] == 'L' {
+		// Las particiones lógicas se marcan como desmontadas en su EBR, liberando su id
+		ebr, err := mbr.GetLogicalPartitionByName(strings.Trim(string(partition.Part_name[:]), "\x00 "), partitionPath)
+		if err != nil {
+			return err
+		}
+		ebr.Unmount()
+
+		err = ebr.Serialize(partitionPath, int64(ebr.Part_start))
+		if err != nil {
+			fmt.Println("Error serializando el EBR:", err)
+			return err
+		}
+	} else {
+		// Restablecer el estado, correlativo e id de la partición en el MBR
+		mbrPartition, err := mbr.GetPartitionByID(unmount.id)
+		if err != nil {
+			return err
+		}
+		mbrPartition.UnmountPartition()
+
+		err = mbr.Serialize(partitionPath)
+		if err != nil {
+			fmt.Println("Error serializando el MBR:", err)
+			return err
+		}
+	}
+
+	// Cerrar la sesión si el usuario está logueado en la partición desmontada
+	if stores.Auth.IsAuthenticated() && stores.Auth.GetPartitionID() == unmount.id {
+		stores.Auth.Logout()
+	}
+
+	// Quitar la partición de la tabla de montajes y liberar su id
+	return stores.RemoveMountedPartition(unmount.id)
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	stores "backend/stores"
+	structures "backend/structures"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Longitud máxima de los nombres de grupos y usuarios, y de las contraseñas
+const maxUsersFieldLength = 10
+
+// usersFile users.txt de la partición con la sesión activa, junto con la partición donde se guarda
+type usersFile struct {
+	*structures.UsersFile
+	sb            *structures.SuperBlock
+	partition     *structures.Partition
+	partitionPath string
+}
+
+// requireRoot verifica que haya una sesión activa con el usuario root
+func requireRoot() error {
+	if !stores.Auth.IsAuthenticated() {
+		return errors.New("no se ha iniciado sesión en ninguna partición")
+	}
+	username, _ := stores.Auth.GetCurrentUser()
+	if username != "root" {
+		return errors.New("solo el usuario root puede ejecutar este comando")
+	}
+	return nil
+}
+
+// validateUsersField verifica que un valor se pueda guardar en un campo de users.txt
+func validateUsersField(field string, value string) error {
+	if value == "" {
+		return fmt.Errorf("el campo %s no puede estar vacío", field)
+	}
+	if len(value) > maxUsersFieldLength {
+		return fmt.Errorf("el campo %s excede el máximo de %d caracteres: %s", field, maxUsersFieldLength, value)
+	}
+	if strings.ContainsAny(value, ",\n") {
+		return fmt.Errorf("el campo %s no puede contener comas ni saltos de línea: %s", field, value)
+	}
+	return nil
+}
+
+// loadUsersFile lee users.txt completo de la partición con la sesión activa
+func loadUsersFile() (*usersFile, error) {
+	return readUsersFile(stores.Auth.GetPartitionID())
+}
+
+// readUsersFile lee users.txt completo de la partición montada con el id indicado
+func readUsersFile(partitionID string) (*usersFile, error) {
+	partitionSuperblock, mountedPartition, partitionPath, err := stores.GetMountedPartitionSuperblock(partitionID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener la partición montada: %w", err)
+	}
+
+	users, err := partitionSuperblock.ReadUsersFile(partitionPath)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer %s: %w", structures.UsersFilePath, err)
+	}
+
+	return &usersFile{
+		UsersFile:     users,
+		sb:            partitionSuperblock,
+		partition:     mountedPartition,
+		partitionPath: partitionPath,
+	}, nil
+}
+
+// save escribe users.txt en el disco y registra la operación en el journaling si es EXT3
+func (u *usersFile) save(operation string, journalContent string) error {
+	err := u.sb.WriteUsersFile(u.partitionPath, u.UsersFile)
+	if err != nil {
+		return fmt.Errorf("error al escribir %s: %w", structures.UsersFilePath, err)
+	}
+
+	// Registrar la operación en el journaling si la partición es EXT3
+	if u.sb.S_filesystem_type == 3 {
+		err = u.sb.AddJournal(u.partitionPath, operation, structures.UsersFilePath, journalContent)
+		if err != nil {
+			return fmt.Errorf("error al registrar el journaling: %w", err)
+		}
+	}
+
+	// Serializar el superbloque
+	err = u.sb.Serialize(u.partitionPath, int64(u.partition.Part_start))
+	if err != nil {
+		return fmt.Errorf("error al serializar el superbloque: %w", err)
+	}
+
+	return nil
+}
\ No newline at end of file
@@ -2,8 +2,8 @@ package main
 
 import (
 	analyzer "backend/analyzer"
+	stores "backend/stores"
 	"fmt"
-	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -14,10 +14,16 @@ type CommandRequest struct {
 }
 
 type CommandResponse struct {
-	Output string `json:"output"`
+	Output  string                `json:"output"`
+	Results []analyzer.LineResult `json:"results"`
 }
 
 func main() {
+	// Restaurar las particiones montadas antes del último reinicio
+	if err := stores.LoadMountedPartitions(); err != nil {
+		fmt.Println("Error al cargar las particiones montadas:", err)
+	}
+
 	app := fiber.New()
 
 	app.Use(cors.New(cors.Config{}))
@@ -30,28 +36,17 @@ func main() {
 			})
 		}
 
-		commands := strings.Split(req.Command, "\n")
-		output := ""
-
-		for _, cmd := range commands {
-			if strings.TrimSpace(cmd) == "" {
-				continue
-			}
-
-			result, err := analyzer.Analyzer(cmd)
-			if err != nil {
-				output += fmt.Sprintf("Error: %s\n", err.Error())
-			} else {
-				output += fmt.Sprintf("%s\n", result)
-			}
-		}
+		// Ejecutar el script línea por línea
+		results := analyzer.RunScript(req.Command)
+		output := analyzer.FormatResults(results)
 
-		if output == "" {
+		if len(results) == 0 {
 			output = "No se ejecutó ningún comando"
 		}
 
 		return c.JSON(CommandResponse{
-			Output: output,
+			Output:  output,
+			Results: results,
 		})
 	})
 
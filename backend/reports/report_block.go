@@ -57,8 +57,14 @@ func ReportBlock(sb *structures.SuperBlock, diskPath, outPath string) error {
 	// Se almacenan los bloques descubiertos
 	visited := make(map[int32]*blockInfo)
 
+	// Obtener los inodos ocupados según el bitmap
+	usedInodes, err := sb.UsedInodes(diskPath)
+	if err != nil {
+		return fmt.Errorf("error al leer el bitmap de inodos: %v", err)
+	}
+
 	// Recorrer todos los inodos
-	for i := int32(0); i < sb.S_inodes_count; i++ {
+	for _, i := range usedInodes {
 		inode := &structures.Inode{}
 		offset := sb.S_inode_start + i*sb.S_inode_size
 		if err := inode.Deserialize(diskPath, int64(offset)); err != nil {
@@ -73,9 +79,12 @@ func ReportBlock(sb *structures.SuperBlock, diskPath, outPath string) error {
 			processBlock(sb, diskPath, inode.I_block[b], inode.I_type[0], visited)
 		}
 
-		// Procesar bloques indirectos
-		if inode.I_block[12] != -1 {
-			handlePointerBlock(sb, diskPath, inode.I_block[12], visited)
+		// Procesar bloques indirectos: I_block[12] simple, [13] doble y [14] triple
+		for b := 12; b < 15; b++ {
+			if inode.I_block[b] == -1 {
+				continue
+			}
+			handlePointerBlock(sb, diskPath, inode.I_block[b], b-11, inode.I_type[0], visited)
 		}
 	}
 
@@ -158,7 +167,7 @@ func renderFolderBlock(nodeName string, idx int32, fb *structures.FolderBlock, c
 	`, nodeName, colors.EvenRow, colors.Folder, idx, colors.Primary, colors.Primary))
 
 	for i, c := range fb.B_content {
-		name := strings.Trim(string(c.B_name[:]), "\x00 ")
+		name := c.Name()
 		if name == "" {
 			continue
 		}
@@ -257,8 +266,9 @@ func processBlock(sb *structures.SuperBlock, diskPath string, blkIndex int32, in
 	}
 }
 
-// handlePointerBlock procesa bloques de punteros recursivamente
-func handlePointerBlock(sb *structures.SuperBlock, diskPath string, blockIndex int32, visited map[int32]*blockInfo) {
+// handlePointerBlock procesa bloques de punteros recursivamente; level indica cuántos
+// niveles de apuntadores quedan hasta llegar a los bloques de datos del inodo
+func handlePointerBlock(sb *structures.SuperBlock, diskPath string, blockIndex int32, level int, inodeType byte, visited map[int32]*blockInfo) {
 	if _, ok := visited[blockIndex]; ok {
 		return
 	}
@@ -277,8 +287,13 @@ func handlePointerBlock(sb *structures.SuperBlock, diskPath string, blockIndex i
 
 	// Procesar bloques apuntados
 	for _, ptr := range pb.P_pointers {
-		if ptr != -1 {
-			processBlock(sb, diskPath, ptr, '0', visited) // Asumimos que apuntan a carpetas
+		if ptr == -1 {
+			continue
+		}
+		if level > 1 {
+			handlePointerBlock(sb, diskPath, ptr, level-1, inodeType, visited)
+		} else {
+			processBlock(sb, diskPath, ptr, inodeType, visited)
 		}
 	}
 }
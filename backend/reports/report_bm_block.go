@@ -23,8 +23,8 @@ func ReportBMBlock(superblock *structures.SuperBlock, diskPath string, path stri
     }
     defer file.Close()
 
-    // Obtener la cantidad total de bloques
-    totalBlocks := superblock.S_blocks_count
+    // Obtener la cantidad total de bloques (usados y libres)
+    totalBlocks := superblock.TotalBlocks()
 
     // Construir el contenido del bitmap
     var bitmapContent strings.Builder
@@ -43,9 +43,11 @@ func ReportBMBlock(superblock *structures.SuperBlock, diskPath string, path stri
             return fmt.Errorf("error al leer el byte del archivo: %v", err)
         }
 
-        // Validar el carácter leído
-        if char[0] != '0' && char[0] != '1' {
-            char[0] = '0' // Corregir a '0' si no es válido
+        // Normalizar el carácter leído a '0' o '1' (los discos anteriores usaban 'O' y 'X')
+        if structures.IsBitmapUsed(char[0]) {
+            char[0] = '1'
+        } else {
+            char[0] = '0'
         }
 
         // Escribir el bit en el contenido
@@ -24,7 +24,7 @@ func ReportBMInode(superblock *structures.SuperBlock, diskPath string, path stri
 	defer file.Close()
 
 	// Calcular el número total de inodos
-	totalInodes := superblock.S_inodes_count + superblock.S_free_inodes_count
+	totalInodes := superblock.TotalInodes()
 
 	// Obtener el contenido del bitmap de inodos
 	var bitmapContent strings.Builder
@@ -51,8 +51,14 @@ func ReportInode(superblock *structures.SuperBlock, diskPath string, path string
         accent6:     "#1abc9c",
     }
 
+    // Obtener los inodos ocupados según el bitmap
+    usedInodes, err := superblock.UsedInodes(diskPath)
+    if err != nil {
+        return err
+    }
+
     // Iterar sobre cada inodo
-    for i := int32(0); i < superblock.S_inodes_count; i++ {
+    for n, i := range usedInodes {
         inode := &structures.Inode{}
         // Deserializar el inodo
         err := inode.Deserialize(diskPath, int64(superblock.S_inode_start+(i*superblock.S_inode_size)))
@@ -171,7 +177,7 @@ func ReportInode(superblock *structures.SuperBlock, diskPath string, path string
         colors.accent6, colors.oddRow, inode.I_block[14])
 
         // Agregar enlace al siguiente inodo con estilo mejorado
-        if i < superblock.S_inodes_count-1 {
+        if n < len(usedInodes)-1 {
             dotContent += fmt.Sprintf(`
                 inode%d -> inode%d [
                     color="%s",
@@ -180,7 +186,7 @@ func ReportInode(superblock *structures.SuperBlock, diskPath string, path string
                     arrowsize=0.8,
                     style="dashed"
                 ];
-            `, i, i+1, colors.accent1)
+            `, i, usedInodes[n+1], colors.accent1)
         }
     }
 
@@ -3,39 +3,47 @@ package stores
 type AuthStore struct {
 	IsLoggedIn  bool
 	Username    string
-	Password    string
 	PartitionID string
+	UID         int32
+	GID         int32
 }
 
 var Auth = &AuthStore{
 	IsLoggedIn:  false,
 	Username:    "",
-	Password:    "",
 	PartitionID: "",
+	UID:         0,
+	GID:         0,
 }
 
-func (a *AuthStore) Login(username, password, partitionID string) {
+func (a *AuthStore) Login(username, partitionID string, uid, gid int32) {
 	a.IsLoggedIn = true
 	a.Username = username
-	a.Password = password
 	a.PartitionID = partitionID
+	a.UID = uid
+	a.GID = gid
 }
 
 func (a *AuthStore) Logout() {
 	a.IsLoggedIn = false
 	a.Username = ""
-	a.Password = ""
 	a.PartitionID = ""
+	a.UID = 0
+	a.GID = 0
 }
 
 func (a *AuthStore) IsAuthenticated() bool {
 	return a.IsLoggedIn
 }
 
-func (a *AuthStore) GetCurrentUser() (string, string, string) {
-	return a.Username, a.Password, a.PartitionID
+func (a *AuthStore) GetCurrentUser() (string, string) {
+	return a.Username, a.PartitionID
 }
 
 func (a *AuthStore) GetPartitionID() string {
 	return a.PartitionID
+}
+
+func (a *AuthStore) GetUserIDs() (int32, int32) {
+	return a.UID, a.GID
 }
\ No newline at end of file
@@ -0,0 +1,159 @@
+package stores
+
+import (
+	structures "backend/structures"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Lista con todo el abecedario
+var alphabet = []string{
+	"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L", "M",
+	"N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z",
+}
+
+// NextPartitionID genera el id para montar una partición del disco indicado.
+// La letra y los correlativos en uso se reconstruyen a partir de los Part_id guardados
+// en el MBR y los EBRs del disco y de la tabla de montajes, por lo que no dependen del orden de montaje
+func NextPartitionID(path string, mbr *structures.MBR) (string, int, error) {
+	letter, used := diskLetterAndCorrelatives(path, mbr)
+
+	// Si el disco no tiene letra asignada, tomar la primera libre
+	if letter == "" {
+		letter = nextAvailableLetter()
+		if letter == "" {
+			return "", 0, errors.New("no hay más letras disponibles para asignar")
+		}
+	}
+
+	// Obtener el menor correlativo libre del disco
+	correlative := 1
+	for used[correlative] {
+		correlative++
+	}
+
+	return fmt.Sprintf("%s%d%s", Carnet, correlative, letter), correlative, nil
+}
+
+// StoredPartitionID devuelve el id guardado en el MBR o en el EBR para una partición que quedó montada
+// antes de un reinicio, siempre que siga siendo válido y no esté en uso en la tabla de montajes
+func StoredPartitionID(path string, partition *structures.Partition) (string, bool) {
+	if partition.Part_status[0] != '1' {
+		return "", false
+	}
+
+	id := strings.Trim(string(partition.Part_id[:]), "\x00 ")
+	letter, correlative, err := parsePartitionID(id)
+	if err != nil || correlative != int(partition.Part_correlative) {
+		return "", false
+	}
+
+	// El id no puede estar ocupado por otra partición montada
+	if _, exists := MountedPartitions[id]; exists {
+		return "", false
+	}
+
+	// La letra no puede estar asignada a otro disco
+	if owner := letterOwner(letter); owner != "" && owner != path {
+		return "", false
+	}
+
+	return id, true
+}
+
+// diskLetterAndCorrelatives obtiene la letra del disco y los correlativos que ya están en uso
+func diskLetterAndCorrelatives(path string, mbr *structures.MBR) (string, map[int]bool) {
+	letter := ""
+	used := make(map[int]bool)
+
+	// Los montajes activos del disco definen su letra
+	for id, mounted := range MountedPartitions {
+		if mounted.Path != path {
+			continue
+		}
+		mountedLetter, correlative, err := parsePartitionID(id)
+		if err != nil {
+			continue
+		}
+		letter = mountedLetter
+		used[correlative] = true
+	}
+
+	// Los ids que quedaron guardados en el MBR y en los EBRs reservan su correlativo y, si el disco
+	// no tiene montajes activos, su letra (siempre que no la use otro disco)
+	for _, partition := range diskPartitions(path, mbr) {
+		if partition.Part_status[0] != '1' {
+			continue
+		}
+		id := strings.Trim(string(partition.Part_id[:]), "\x00 ")
+		storedLetter, correlative, err := parsePartitionID(id)
+		if err != nil {
+			continue
+		}
+		used[correlative] = true
+		if letter == "" && letterOwner(storedLetter) == "" {
+			letter = storedLetter
+		}
+	}
+
+	return letter, used
+}
+
+// diskPartitions devuelve las particiones del MBR junto con las particiones lógicas de la extendida
+func diskPartitions(path string, mbr *structures.MBR) []*structures.Partition {
+	var partitions []*structures.Partition
+	for i := range mbr.Mbr_partitions {
+		partitions = append(partitions, &mbr.Mbr_partitions[i])
+	}
+
+	// Si no hay extendida o su cadena está rota, solo se consideran las del MBR
+	ebrs, _ := mbr.GetLogicalPartitions(path)
+	for i := range ebrs {
+		if ebrs[i].Part_s > 0 {
+			partitions = append(partitions, ebrs[i].ToPartition())
+		}
+	}
+
+	return partitions
+}
+
+// nextAvailableLetter obtiene la primera letra del abecedario que no está asignada a ningún disco
+func nextAvailableLetter() string {
+	for _, letter := range alphabet {
+		if letterOwner(letter) == "" {
+			return letter
+		}
+	}
+	return ""
+}
+
+// letterOwner devuelve el path del disco que tiene asignada la letra en la tabla de montajes
+func letterOwner(letter string) string {
+	for id, mounted := range MountedPartitions {
+		if strings.HasSuffix(id, letter) {
+			return mounted.Path
+		}
+	}
+	return ""
+}
+
+// parsePartitionID separa un id de partición (carnet + correlativo + letra) en su letra y correlativo
+func parsePartitionID(id string) (string, int, error) {
+	if len(id) < len(Carnet)+2 || !strings.HasPrefix(id, Carnet) {
+		return "", 0, fmt.Errorf("id de partición inválido: %s", id)
+	}
+
+	letter := id[len(id)-1:]
+	if letter < "A" || letter > "Z" {
+		return "", 0, fmt.Errorf("id de partición inválido: %s", id)
+	}
+
+	correlative, err := strconv.Atoi(id[len(Carnet) : len(id)-1])
+	if err != nil || correlative < 1 {
+		return "", 0, fmt.Errorf("id de partición inválido: %s", id)
+	}
+
+	return letter, correlative, nil
+}
@@ -0,0 +1,169 @@
+package stores
+
+import (
+	structures "backend/structures"
+	utils "backend/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Archivo donde se guarda la tabla de particiones montadas para conservarla entre reinicios.
+// Se puede indicar con la variable de entorno MOUNT_STATE_PATH y siempre se guarda como ruta
+// absoluta, para que no dependa del directorio de trabajo al momento de escribirlo
+var MountStatePath = mountStatePath(os.Getenv("MOUNT_STATE_PATH"))
+
+// mountStatePath convierte la ruta del archivo de estado en absoluta; si no se indicó ninguna,
+// se usa mounted_partitions.json en el directorio desde el que se inició el servidor
+func mountStatePath(path string) string {
+	if path == "" {
+		path = "mounted_partitions.json"
+	}
+
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return absolute
+}
+
+// AddMountedPartition registra una partición montada y guarda la tabla de montajes
+func AddMountedPartition(id string, path string, name string, typ string) error {
+	MountedPartitions[id] = &MountedPartition{Path: path, Name: name, Type: typ, MountTime: time.Now().Unix()}
+	return SaveMountedPartitions()
+}
+
+// IsPartitionMounted indica si la partición del disco ya está en la tabla de montajes.
+// Se compara el inicio de cada partición montada, ya que el nombre no distingue mayúsculas
+func IsPartitionMounted(path string, mbr *structures.MBR, partition *structures.Partition) bool {
+	for _, mounted := range MountedPartitions {
+		if mounted.Path != path {
+			continue
+		}
+		mountedPartition, _ := mbr.GetPartitionByName(mounted.Name, path)
+		if mountedPartition != nil && mountedPartition.Part_start == partition.Part_start {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveMountedPartition quita una partición de la tabla de montajes
+func RemoveMountedPartition(id string) error {
+	if _, exists := MountedPartitions[id]; !exists {
+		return errors.New("la partición no está montada")
+	}
+
+	delete(MountedPartitions, id)
+	return SaveMountedPartitions()
+}
+
+// SaveMountedPartitions escribe la tabla de particiones montadas en el archivo de estado
+func SaveMountedPartitions() error {
+	data, err := json.MarshalIndent(MountedPartitions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(MountStatePath, data, 0644)
+	if err != nil {
+		return fmt.Errorf("error al guardar la tabla de montajes: %w", err)
+	}
+	return nil
+}
+
+// LoadMountedPartitions carga la tabla de particiones montadas desde el archivo de estado.
+// Cada montaje se contrasta con el id guardado en el MBR o en el EBR de la partición
+func LoadMountedPartitions() error {
+	data, err := os.ReadFile(MountStatePath)
+	if err != nil {
+		// Si no existe el archivo, no hay particiones montadas
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	loaded := make(map[string]*MountedPartition)
+	err = json.Unmarshal(data, &loaded)
+	if err != nil {
+		return fmt.Errorf("error al leer la tabla de montajes: %w", err)
+	}
+
+	// Cargar los montajes en orden para que los conflictos se resuelvan siempre igual
+	ids := make([]string, 0, len(loaded))
+	for id := range loaded {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		mounted := loaded[id]
+
+		// Descartar los montajes de discos que ya no existen
+		if !utils.FileExists(mounted.Path) {
+			continue
+		}
+
+		// Descartar los ids con formato inválido
+		if _, _, err := parsePartitionID(id); err != nil {
+			continue
+		}
+
+		// Descartar los ids que chocan con el MBR o con otro montaje
+		if err := checkLoadedMount(id, mounted); err != nil {
+			fmt.Printf("Montaje %s descartado: %v\n", id, err)
+			continue
+		}
+
+		MountedPartitions[id] = mounted
+	}
+
+	return nil
+}
+
+// checkLoadedMount verifica que un montaje del archivo de estado corresponda a una partición del disco
+// y que su id no esté asignado en el MBR o en un EBR a otra partición ni choque con los montajes ya cargados
+func checkLoadedMount(id string, mounted *MountedPartition) error {
+	var mbr structures.MBR
+	err := mbr.Deserialize(mounted.Path)
+	if err != nil {
+		return err
+	}
+
+	partition, _ := mbr.GetPartitionByName(mounted.Name, mounted.Path)
+	if partition == nil {
+		return errors.New("la partición ya no existe")
+	}
+	if (partition.Part_type[0] == 'L') != (mounted.Type == "L") {
+		return errors.New("el tipo de la partición no coincide")
+	}
+
+	// Las particiones montadas guardan su id en el MBR o en su EBR, que no puede repetirse en otra partición
+	for _, stored := range diskPartitions(mounted.Path, &mbr) {
+		storedID := strings.Trim(string(stored.Part_id[:]), "\x00 ")
+		samePartition := stored.Part_start == partition.Part_start
+		if samePartition && (stored.Part_status[0] != '1' || storedID != id) {
+			return errors.New("el id no coincide con el guardado en el disco")
+		}
+		if !samePartition && stored.Part_status[0] == '1' && storedID == id {
+			return fmt.Errorf("el id está asignado a la partición %s", strings.Trim(string(stored.Part_name[:]), "\x00 "))
+		}
+	}
+
+	// La letra identifica a un solo disco y cada partición se monta una sola vez
+	letter, _, _ := parsePartitionID(id)
+	if owner := letterOwner(letter); owner != "" && owner != mounted.Path {
+		return fmt.Errorf("la letra %s está asignada a otro disco", letter)
+	}
+	if IsPartitionMounted(mounted.Path, &mbr, partition) {
+		return errors.New("la partición ya está montada con otro id")
+	}
+
+	return nil
+}
\ No newline at end of file
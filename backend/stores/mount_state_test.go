@@ -0,0 +1,215 @@
+package stores
+
+import (
+	structures "backend/structures"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestDisk crea un disco con una partición primaria Part1 y una lógica Log1 dentro de la extendida Ext
+func newTestDisk(t *testing.T) (string, *structures.MBR) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "disco.mia")
+	mbr := &structures.MBR{Mbr_size: 64 * 1024, Mbr_disk_signature: 1, Mbr_disk_fit: [1]byte{'F'}}
+	for i := range mbr.Mbr_partitions {
+		mbr.Mbr_partitions[i] = structures.Partition{Part_status: [1]byte{'N'}, Part_start: -1, Part_size: -1, Part_correlative: -1}
+	}
+	mbr.Mbr_partitions[0].CreatePartition(1024, 16*1024, "P", "F", "Part1")
+	mbr.Mbr_partitions[1].CreatePartition(17*1024, 32*1024, "E", "F", "Ext")
+
+	if err := os.WriteFile(path, make([]byte, mbr.Mbr_size), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeTestMBR(t, path, mbr)
+
+	// El primer EBR de la extendida describe la partición lógica
+	ebr := &structures.EBR{}
+	ebr.CreateEBR(17*1024, 8*1024, "F", "Log1")
+	ebr.Part_next = -1
+	if err := ebr.Serialize(path, int64(ebr.Part_start)); err != nil {
+		t.Fatal(err)
+	}
+
+	return path, mbr
+}
+
+// writeTestMBR escribe el MBR al inicio del disco sin validar la tabla de particiones
+func writeTestMBR(t *testing.T, path string, mbr *structures.MBR) {
+	t.Helper()
+
+	file, err := os.OpenFile(path, os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if err := binary.Write(file, binary.LittleEndian, mbr); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// mountTestLogical guarda en el EBR de Log1 el id con el que quedó montada
+func mountTestLogical(t *testing.T, path string, mbr *structures.MBR, correlative int, id string) {
+	t.Helper()
+
+	ebr, err := mbr.GetLogicalPartitionByName("Log1", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ebr.Mount(correlative, id)
+	if err := ebr.Serialize(path, int64(ebr.Part_start)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// resetMountState deja la tabla de montajes vacía y guarda el estado en un archivo temporal
+func resetMountState(t *testing.T) {
+	t.Helper()
+
+	previousPath, previous := MountStatePath, MountedPartitions
+	MountStatePath = filepath.Join(t.TempDir(), "mounted_partitions.json")
+	MountedPartitions = make(map[string]*MountedPartition)
+	t.Cleanup(func() {
+		MountStatePath, MountedPartitions = previousPath, previous
+	})
+}
+
+func TestIsPartitionMountedIgnoresNameCase(t *testing.T) {
+	resetMountState(t)
+	path, mbr := newTestDisk(t)
+
+	if err := AddMountedPartition("961A", path, "part1", "P"); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddMountedPartition("962A", path, "LOG1", "L"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"Part1", "PART1", "Log1", "log1"} {
+		partition, _ := mbr.GetPartitionByName(name, path)
+		if partition == nil {
+			t.Fatalf("no se encontró la partición %s", name)
+		}
+		if !IsPartitionMounted(path, mbr, partition) {
+			t.Errorf("%s debería estar montada", name)
+		}
+	}
+
+	extended, _ := mbr.GetPartitionByName("Ext", path)
+	if IsPartitionMounted(path, mbr, extended) {
+		t.Error("Ext no debería estar montada")
+	}
+}
+
+
+func TestLoadMountedPartitionsKeepsLogicalIDs(t *testing.T) {
+	resetMountState(t)
+	path, mbr := newTestDisk(t)
+
+	// Part1 guarda su id en el MBR y Log1 en su EBR
+	mbr.Mbr_partitions[0].MountPartition(1, "961A")
+	writeTestMBR(t, path, mbr)
+	mountTestLogical(t, path, mbr, 2, "962A")
+	if err := AddMountedPartition("961A", path, "Part1", "P"); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddMountedPartition("962A", path, "Log1", "L"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simular un reinicio
+	MountedPartitions = make(map[string]*MountedPartition)
+	if err := LoadMountedPartitions(); err != nil {
+		t.Fatal(err)
+	}
+
+	if mounted := MountedPartitions["962A"]; mounted == nil || mounted.Name != "Log1" {
+		t.Fatalf("962A = %+v; se esperaba Log1", mounted)
+	}
+	if MountedPartitions["961A"] == nil {
+		t.Fatal("961A no se restauró")
+	}
+
+	// El siguiente montaje del disco no puede reutilizar el correlativo de la lógica
+	id, _, err := NextPartitionID(path, mbr)
+	if err != nil || id != "963A" {
+		t.Fatalf("NextPartitionID = %s, %v; se esperaba 963A", id, err)
+	}
+}
+
+func TestLoadMountedPartitionsRejectsConflictingIDs(t *testing.T) {
+	resetMountState(t)
+	path, mbr := newTestDisk(t)
+
+	// Log1 quedó en el archivo con el id que el MBR tiene asignado a Part1
+	mbr.Mbr_partitions[0].MountPartition(1, "961A")
+	writeTestMBR(t, path, mbr)
+	mountTestLogical(t, path, mbr, 2, "962A")
+	if err := AddMountedPartition("961A", path, "Log1", "L"); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddMountedPartition("962A", path, "log1", "L"); err != nil {
+		t.Fatal(err)
+	}
+
+	MountedPartitions = make(map[string]*MountedPartition)
+	if err := LoadMountedPartitions(); err != nil {
+		t.Fatal(err)
+	}
+
+	if MountedPartitions["961A"] != nil {
+		t.Error("961A no debería cargarse para Log1: el MBR lo asigna a Part1")
+	}
+	if MountedPartitions["962A"] == nil {
+		t.Error("962A debería cargarse para Log1")
+	}
+	if len(MountedPartitions) != 1 {
+		t.Errorf("se cargaron %d montajes; se esperaba 1", len(MountedPartitions))
+	}
+}
+
+func TestLogicalIDSurvivesLostMountState(t *testing.T) {
+	resetMountState(t)
+	path, mbr := newTestDisk(t)
+	mountTestLogical(t, path, mbr, 2, "962A")
+
+	// Sin archivo de estado, el id de Log1 se recupera de su EBR
+	partition, _ := mbr.GetPartitionByName("Log1", path)
+	if partition == nil {
+		t.Fatal("no se encontró Log1")
+	}
+	if id, ok := StoredPartitionID(path, partition); !ok || id != "962A" {
+		t.Fatalf("StoredPartitionID = %s, %v; se esperaba 962A", id, ok)
+	}
+
+	// El correlativo de la lógica queda reservado para los demás montajes del disco
+	id, _, err := NextPartitionID(path, mbr)
+	if err != nil || id != "961A" {
+		t.Fatalf("NextPartitionID = %s, %v; se esperaba 961A", id, err)
+	}
+	if err := AddMountedPartition(id, path, "Part1", "P"); err != nil {
+		t.Fatal(err)
+	}
+	if id, _, err = NextPartitionID(path, mbr); err != nil || id != "963A" {
+		t.Fatalf("NextPartitionID = %s, %v; se esperaba 963A", id, err)
+	}
+
+	// La partición montada se busca por el id guardado en el EBR
+	if err := AddMountedPartition("962A", path, "Log1", "L"); err != nil {
+		t.Fatal(err)
+	}
+	mounted, _, err := GetMountedPartition("962A")
+	if err != nil || mounted.Part_start != partition.Part_start {
+		t.Fatalf("GetMountedPartition = %+v, %v", mounted, err)
+	}
+}
+
+func TestMountStatePathIsAbsolute(t *testing.T) {
+	for _, path := range []string{"", "estado.json", "/tmp/estado.json"} {
+		if got := mountStatePath(path); !filepath.IsAbs(got) {
+			t.Errorf("mountStatePath(%q) = %s; se esperaba una ruta absoluta", path, got)
+		}
+	}
+}
\ No newline at end of file
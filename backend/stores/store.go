@@ -8,18 +8,44 @@ import (
 // Carnet de estudiante
 const Carnet string = "96" // 202300596
 
+// MountedPartition representa una partición montada y el disco al que pertenece
+type MountedPartition struct {
+	Path string `json:"path"` // Ruta del archivo del disco
+	Name string `json:"name"` // Nombre de la partición
+	Type string `json:"type"` // Tipo de partición (P o L)
+
+	MountTime int64 `json:"mount_time"` // Fecha de montaje (Unix)
+}
+
 // Declaración de variables globales
 var (
-	MountedPartitions map[string]string = make(map[string]string)
+	MountedPartitions map[string]*MountedPartition = make(map[string]*MountedPartition)
 )
 
+// findPartitionByID busca la partición montada en el MBR o, si es lógica, en la cadena de EBRs
+func findPartitionByID(mbr *structures.MBR, id string, mounted *MountedPartition) (*structures.Partition, error) {
+	// Las particiones primarias guardan su id en el MBR
+	if mounted.Type != "L" {
+		return mbr.GetPartitionByID(id)
+	}
+
+	// Las particiones lógicas guardan su id en su EBR
+	ebr, err := mbr.GetLogicalPartitionByID(id, mounted.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ebr.ToPartition(), nil
+}
+
 // GetMountedPartition obtiene la partición montada con el id especificado
 func GetMountedPartition(id string) (*structures.Partition, string, error) {
 	// Obtener el path de la partición montada
-	path := MountedPartitions[id]
-	if path == "" {
+	mounted := MountedPartitions[id]
+	if mounted == nil {
 		return nil, "", errors.New("la partición no está montada")
 	}
+	path := mounted.Path
 
 	// Crear una instancia de MBR
 	var mbr structures.MBR
@@ -31,7 +57,7 @@ func GetMountedPartition(id string) (*structures.Partition, string, error) {
 	}
 
 	// Buscar la partición con el id especificado
-	partition, err := mbr.GetPartitionByID(id)
+	partition, err := findPartitionByID(&mbr, id, mounted)
 	if partition == nil {
 		return nil, "", err
 	}
@@ -42,10 +68,11 @@ func GetMountedPartition(id string) (*structures.Partition, string, error) {
 // GetMountedMBR obtiene el MBR de la partición montada con el id especificado
 func GetMountedPartitionRep(id string) (*structures.MBR, *structures.SuperBlock, string, error) {
 	// Obtener el path de la partición montada
-	path := MountedPartitions[id]
-	if path == "" {
+	mounted := MountedPartitions[id]
+	if mounted == nil {
 		return nil, nil, "", errors.New("la partición no está montada")
 	}
+	path := mounted.Path
 
 	// Crear una instancia de MBR
 	var mbr structures.MBR
@@ -57,7 +84,7 @@ func GetMountedPartitionRep(id string) (*structures.MBR, *structures.SuperBlock,
 	}
 
 	// Buscar la partición con el id especificado
-	partition, err := mbr.GetPartitionByID(id)
+	partition, err := findPartitionByID(&mbr, id, mounted)
 	if partition == nil {
 		return nil, nil, "", err
 	}
@@ -77,10 +104,11 @@ func GetMountedPartitionRep(id string) (*structures.MBR, *structures.SuperBlock,
 // GetMountedPartitionSuperblock obtiene el SuperBlock de la partición montada con el id especificado
 func GetMountedPartitionSuperblock(id string) (*structures.SuperBlock, *structures.Partition, string, error) {
 	// Obtener el path de la partición montada
-	path := MountedPartitions[id]
-	if path == "" {
+	mounted := MountedPartitions[id]
+	if mounted == nil {
 		return nil, nil, "", errors.New("la partición no está montada")
 	}
+	path := mounted.Path
 
 	// Crear una instancia de MBR
 	var mbr structures.MBR
@@ -92,7 +120,7 @@ func GetMountedPartitionSuperblock(id string) (*structures.SuperBlock, *structur
 	}
 
 	// Buscar la partición con el id especificado
-	partition, err := mbr.GetPartitionByID(id)
+	partition, err := findPartitionByID(&mbr, id, mounted)
 	if partition == nil {
 		return nil, nil, "", err
 	}
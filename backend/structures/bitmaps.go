@@ -2,6 +2,8 @@ package structures
 
 import (
 	"encoding/binary"
+	"errors"
+	"fmt"
 	"os"
 )
 
@@ -40,10 +42,10 @@ func (sb *SuperBlock) CreateBitMaps(path string) error {
 		return err
 	}
 
-	// Crear un buffer de n 'O'
+	// Crear un buffer de n '0'
 	buffer = make([]byte, sb.S_free_blocks_count)
 	for i := range buffer {
-		buffer[i] = 'O'
+		buffer[i] = '0'
 	}
 
 	// Escribir el buffer en el archivo
@@ -55,50 +57,190 @@ func (sb *SuperBlock) CreateBitMaps(path string) error {
 	return nil
 }
 
-// Actualizar Bitmap de inodos
-func (sb *SuperBlock) UpdateBitmapInode(path string) error {
-	// Abrir el archivo
-	file, err := os.OpenFile(path, os.O_RDWR, 0644)
+// TotalInodes obtiene la cantidad total de inodos de la partición (usados y libres)
+func (sb *SuperBlock) TotalInodes() int32 {
+	return sb.S_inodes_count + sb.S_free_inodes_count
+}
+
+// TotalBlocks obtiene la cantidad total de bloques de la partición (usados y libres)
+func (sb *SuperBlock) TotalBlocks() int32 {
+	return sb.S_blocks_count + sb.S_free_blocks_count
+}
+
+// IsBitmapUsed indica si un byte del bitmap marca el inodo o bloque como ocupado.
+// Se acepta 'X' por compatibilidad con los discos formateados anteriormente, donde 'O' marcaba un bloque libre
+func IsBitmapUsed(bit byte) bool {
+	return bit == '1' || bit == 'X'
+}
+
+// AllocateInode reserva el primer inodo libre del bitmap y devuelve su índice
+func (sb *SuperBlock) AllocateInode(path string) (int32, error) {
+	index, next, err := allocateBit(path, sb.S_bm_inode_start, sb.TotalInodes())
 	if err != nil {
-		return err
+		return -1, err
+	}
+	if index == -1 {
+		return -1, errors.New("no hay inodos libres en la partición")
 	}
-	defer file.Close()
 
-	// Mover el puntero del archivo a la posición del bitmap de inodos
-	_, err = file.Seek(int64(sb.S_bm_inode_start)+int64(sb.S_inodes_count), 0)
+	// Actualizar el superbloque
+	sb.S_inodes_count++
+	sb.S_free_inodes_count--
+	sb.S_first_ino = firstFreeOffset(next, sb.S_inode_start, sb.S_inode_size)
+
+	return index, nil
+}
+
+// AllocateBlock reserva el primer bloque libre del bitmap y devuelve su índice
+func (sb *SuperBlock) AllocateBlock(path string) (int32, error) {
+	index, next, err := allocateBit(path, sb.S_bm_block_start, sb.TotalBlocks())
 	if err != nil {
+		return -1, err
+	}
+	if index == -1 {
+		return -1, errors.New("no hay bloques libres en la partición")
+	}
+
+	// Actualizar el superbloque
+	sb.S_blocks_count++
+	sb.S_free_blocks_count--
+	sb.S_first_blo = firstFreeOffset(next, sb.S_block_start, sb.S_block_size)
+
+	return index, nil
+}
+
+// FreeInode libera un inodo en el bitmap
+func (sb *SuperBlock) FreeInode(path string, index int32) error {
+	freed, err := freeBit(path, sb.S_bm_inode_start, sb.TotalInodes(), index)
+	if err != nil || !freed {
 		return err
 	}
 
-	// Escribir el bit en el archivo
-	_, err = file.Write([]byte{'1'})
-	if err != nil {
+	// Actualizar el superbloque
+	sb.S_inodes_count--
+	sb.S_free_inodes_count++
+	offset := sb.S_inode_start + index*sb.S_inode_size
+	if sb.S_first_ino == -1 || offset < sb.S_first_ino {
+		sb.S_first_ino = offset
+	}
+
+	return nil
+}
+
+// FreeBlock libera un bloque en el bitmap
+func (sb *SuperBlock) FreeBlock(path string, index int32) error {
+	freed, err := freeBit(path, sb.S_bm_block_start, sb.TotalBlocks(), index)
+	if err != nil || !freed {
 		return err
 	}
 
+	// Actualizar el superbloque
+	sb.S_blocks_count--
+	sb.S_free_blocks_count++
+	offset := sb.S_block_start + index*sb.S_block_size
+	if sb.S_first_blo == -1 || offset < sb.S_first_blo {
+		sb.S_first_blo = offset
+	}
+
 	return nil
 }
 
-// Actualizar Bitmap de bloques
-func (sb *SuperBlock) UpdateBitmapBlock(path string) error {
-	// Abrir el archivo
+// UsedInodes obtiene los índices de los inodos marcados como ocupados en el bitmap
+func (sb *SuperBlock) UsedInodes(path string) ([]int32, error) {
+	bitmap, err := readBitmap(path, sb.S_bm_inode_start, sb.TotalInodes())
+	if err != nil {
+		return nil, err
+	}
+
+	var used []int32
+	for i, bit := range bitmap {
+		if IsBitmapUsed(bit) {
+			used = append(used, int32(i))
+		}
+	}
+	return used, nil
+}
+
+// readBitmap lee count bytes del bitmap que inicia en start
+func readBitmap(path string, start int32, count int32) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	bitmap := make([]byte, count)
+	_, err = file.ReadAt(bitmap, int64(start))
+	if err != nil {
+		return nil, err
+	}
+	return bitmap, nil
+}
+
+// writeBit escribe un byte en la posición index del bitmap que inicia en start
+func writeBit(path string, start int32, index int32, bit byte) error {
 	file, err := os.OpenFile(path, os.O_RDWR, 0644)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	// Mover el puntero del archivo a la posición del bitmap de bloques
-	_, err = file.Seek(int64(sb.S_bm_block_start)+int64(sb.S_blocks_count), 0)
+	_, err = file.WriteAt([]byte{bit}, int64(start)+int64(index))
+	return err
+}
+
+// allocateBit marca como ocupado el primer byte libre del bitmap.
+// Devuelve su índice (o -1 si no hay) y el índice del siguiente libre (o -1)
+func allocateBit(path string, start int32, count int32) (int32, int32, error) {
+	bitmap, err := readBitmap(path, start, count)
 	if err != nil {
-		return err
+		return -1, -1, err
+	}
+
+	index := firstFreeBit(bitmap, 0)
+	if index == -1 {
+		return -1, -1, nil
 	}
 
-	// Escribir el bit en el archivo
-	_, err = file.Write([]byte{'X'})
+	err = writeBit(path, start, index, '1')
 	if err != nil {
-		return err
+		return -1, -1, err
 	}
 
-	return nil
+	return index, firstFreeBit(bitmap, index+1), nil
+}
+
+// freeBit marca como libre un byte del bitmap; devuelve falso si ya estaba libre
+func freeBit(path string, start int32, count int32, index int32) (bool, error) {
+	if index < 0 || index >= count {
+		return false, fmt.Errorf("índice fuera del bitmap: %d", index)
+	}
+
+	bitmap, err := readBitmap(path, start+index, 1)
+	if err != nil {
+		return false, err
+	}
+	if !IsBitmapUsed(bitmap[0]) {
+		return false, nil
+	}
+
+	return true, writeBit(path, start, index, '0')
+}
+
+// firstFreeBit obtiene el índice del primer byte libre del bitmap a partir de from, o -1
+func firstFreeBit(bitmap []byte, from int32) int32 {
+	for i := from; i < int32(len(bitmap)); i++ {
+		if !IsBitmapUsed(bitmap[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// firstFreeOffset convierte el índice del primer libre en su posición en bytes (-1 si no hay libres)
+func firstFreeOffset(index int32, start int32, size int32) int32 {
+	if index == -1 {
+		return -1
+	}
+	return start + index*size
 }
\ No newline at end of file
@@ -0,0 +1,247 @@
+package structures
+
+import (
+	"fmt"
+)
+
+// Cantidad de apuntadores de un bloque de apuntadores
+const pointersPerBlock = 16
+
+// Cantidad máxima de bloques de datos de un inodo: 12 directos, indirecto simple, doble y triple
+const MaxFileBlocks = directBlocks + pointersPerBlock + pointersPerBlock*pointersPerBlock + pointersPerBlock*pointersPerBlock*pointersPerBlock
+
+// MapBlock traduce el bloque lógico de un inodo a su bloque físico recorriendo los apuntadores
+// directos e indirectos (I_block[12] simple, [13] doble y [14] triple).
+// Si allocate es verdadero, se crean los bloques de apuntadores y el bloque de datos que falten;
+// si no, devuelve -1 cuando el bloque no está asignado. El inodo se modifica en memoria y
+// el llamador es responsable de serializarlo
+func (sb *SuperBlock) MapBlock(path string, inode *Inode, logical int32, allocate bool) (int32, error) {
+	if logical < 0 || logical >= MaxFileBlocks {
+		return -1, fmt.Errorf("el bloque %d excede el tamaño máximo de un archivo", logical)
+	}
+
+	// Apuntadores directos
+	if logical < directBlocks {
+		if inode.I_block[logical] == -1 && allocate {
+			blockIndex, err := sb.AllocateBlock(path)
+			if err != nil {
+				return -1, err
+			}
+			inode.I_block[logical] = blockIndex
+		}
+		return inode.I_block[logical], nil
+	}
+
+	// Buscar el nivel de indirección del bloque lógico
+	logical -= directBlocks
+	level := 1
+	capacity := int32(pointersPerBlock)
+	for logical >= capacity {
+		logical -= capacity
+		level++
+		capacity *= pointersPerBlock
+	}
+
+	// Obtener (o crear) el bloque de apuntadores raíz del nivel
+	root := &inode.I_block[directBlocks+level-1]
+	if *root == -1 {
+		if !allocate {
+			return -1, nil
+		}
+		blockIndex, err := sb.allocatePointerBlock(path)
+		if err != nil {
+			return -1, err
+		}
+		*root = blockIndex
+	}
+
+	// Descender por los bloques de apuntadores hasta el bloque de datos
+	current := *root
+	for ; level > 0; level-- {
+		capacity /= pointersPerBlock
+		slot := logical / capacity
+		logical %= capacity
+
+		pointerBlock := &PointerBlock{}
+		err := pointerBlock.Deserialize(path, int64(sb.S_block_start+(current*sb.S_block_size)))
+		if err != nil {
+			return -1, err
+		}
+
+		next := pointerBlock.P_pointers[slot]
+		if next == -1 {
+			if !allocate {
+				return -1, nil
+			}
+
+			// El último nivel apunta a bloques de datos, los anteriores a bloques de apuntadores
+			if level == 1 {
+				next, err = sb.AllocateBlock(path)
+			} else {
+				next, err = sb.allocatePointerBlock(path)
+			}
+			if err != nil {
+				return -1, err
+			}
+
+			pointerBlock.P_pointers[slot] = next
+			err = pointerBlock.Serialize(path, int64(sb.S_block_start+(current*sb.S_block_size)))
+			if err != nil {
+				return -1, err
+			}
+		}
+
+		current = next
+	}
+
+	return current, nil
+}
+
+// BlocksRequired calcula cuántos bloques (de datos y de apuntadores) necesita un archivo con dataBlocks bloques de datos
+func BlocksRequired(dataBlocks int32) int32 {
+	total := dataBlocks
+	remaining := dataBlocks - directBlocks
+
+	// Por cada nivel de indirección se cuentan los bloques de apuntadores necesarios
+	capacity := int32(pointersPerBlock)
+	for level := 1; level <= 3 && remaining > 0; level++ {
+		used := remaining
+		if used > capacity {
+			used = capacity
+		}
+		remaining -= used
+
+		// Bloques de apuntadores de cada nivel del árbol: desde las hojas hasta la raíz
+		pointers := used
+		for l := 0; l < level; l++ {
+			pointers = (pointers + pointersPerBlock - 1) / pointersPerBlock
+			total += pointers
+		}
+
+		capacity *= pointersPerBlock
+	}
+
+	return total
+}
+
+// allocatePointerBlock reserva un bloque y lo inicializa como bloque de apuntadores vacío
+func (sb *SuperBlock) allocatePointerBlock(path string) (int32, error) {
+	blockIndex, err := sb.AllocateBlock(path)
+	if err != nil {
+		return -1, err
+	}
+
+	pointerBlock := &PointerBlock{}
+	for i := range pointerBlock.P_pointers {
+		pointerBlock.P_pointers[i] = -1
+	}
+
+	err = pointerBlock.Serialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
+	if err != nil {
+		return -1, err
+	}
+
+	return blockIndex, nil
+}
+
+// TruncateBlocks libera los bloques de datos del inodo desde el bloque lógico from en adelante,
+// junto con los bloques de apuntadores que queden vacíos. El inodo se modifica en memoria y
+// el llamador es responsable de serializarlo
+func (sb *SuperBlock) TruncateBlocks(path string, inode *Inode, from int32) error {
+	if from < 0 {
+		from = 0
+	}
+
+	// Apuntadores directos
+	for i := from; i < directBlocks; i++ {
+		if inode.I_block[i] == -1 {
+			continue
+		}
+		err := sb.FreeBlock(path, inode.I_block[i])
+		if err != nil {
+			return err
+		}
+		inode.I_block[i] = -1
+	}
+
+	// Apuntadores indirectos: cada nivel empieza donde termina el anterior
+	start := int32(directBlocks)
+	capacity := int32(pointersPerBlock)
+	for level := 1; level <= 3; level++ {
+		root := &inode.I_block[directBlocks+level-1]
+		if *root != -1 {
+			empty, err := sb.truncatePointerBlock(path, *root, level, from-start)
+			if err != nil {
+				return err
+			}
+			if empty {
+				err = sb.FreeBlock(path, *root)
+				if err != nil {
+					return err
+				}
+				*root = -1
+			}
+		}
+
+		start += capacity
+		capacity *= pointersPerBlock
+	}
+
+	return nil
+}
+
+// truncatePointerBlock libera los bloques a partir de la posición keep dentro del árbol de apuntadores
+// de blockIndex (con level niveles) y devuelve si el bloque de apuntadores quedó vacío
+func (sb *SuperBlock) truncatePointerBlock(path string, blockIndex int32, level int, keep int32) (bool, error) {
+	pointerBlock := &PointerBlock{}
+	err := pointerBlock.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
+	if err != nil {
+		return false, err
+	}
+
+	// Cantidad de bloques de datos que cubre cada apuntador de este bloque
+	perSlot := int32(1)
+	for l := 1; l < level; l++ {
+		perSlot *= pointersPerBlock
+	}
+
+	empty := true
+	for slot := range pointerBlock.P_pointers {
+		child := pointerBlock.P_pointers[slot]
+		slotStart := int32(slot) * perSlot
+		if child == -1 {
+			continue
+		}
+
+		// El apuntador cubre solo bloques que se conservan
+		if slotStart+perSlot <= keep {
+			empty = false
+			continue
+		}
+
+		freeChild := true
+		if level > 1 {
+			freeChild, err = sb.truncatePointerBlock(path, child, level-1, keep-slotStart)
+			if err != nil {
+				return false, err
+			}
+		}
+		if !freeChild {
+			empty = false
+			continue
+		}
+
+		err = sb.FreeBlock(path, child)
+		if err != nil {
+			return false, err
+		}
+		pointerBlock.P_pointers[slot] = -1
+	}
+
+	err = pointerBlock.Serialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
+	if err != nil {
+		return false, err
+	}
+
+	return empty, nil
+}
\ No newline at end of file
@@ -1,6 +1,11 @@
 package structures
 
-import "fmt"
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+)
 
 type EBR struct {
 	Part_mount [1]byte 	// Bandera de montaje
@@ -9,6 +14,8 @@ type EBR struct {
 	Part_s int32 		// Tamaño de la partición
 	Part_next int32 	// Siguiente EBR
 	Part_name [16]byte 	// Nombre de la partición
+	Part_correlative int32 	// Correlativo de la partición lógica montada
+	Part_id [4]byte 	// ID de la partición lógica montada
 }
 
 // SerializeEBR escribe la estructura EBR al inicio de una partición extendida
@@ -30,8 +37,144 @@ func (ebr *EBR) CreateEBR(ebrStart, ebrSize int, ebrFit, ebrName string) {
 		ebr.Part_fit[0] = ebrFit[0]
 	}
 
-	// Asignar el nombre del EBR
+	// Asignar el nombre del EBR, limpiando el nombre anterior si se reutiliza
+	ebr.Part_name = [16]byte{}
 	copy(ebr.Part_name[:], ebrName)
+
+	// La partición lógica no tiene id hasta que se monte
+	ebr.Part_correlative = -1
+	ebr.Part_id = [4]byte{'N'}
+}
+
+// Mount marca el EBR como montado y guarda el id de la partición lógica, igual que en el MBR para las primarias
+func (ebr *EBR) Mount(correlative int, id string) {
+	ebr.Part_mount[0] = '1'
+	ebr.Part_correlative = int32(correlative)
+	ebr.Part_id = [4]byte{}
+	copy(ebr.Part_id[:], id)
+}
+
+// Unmount marca el EBR como desmontado y libera el correlativo e id de la partición lógica
+func (ebr *EBR) Unmount() {
+	ebr.Part_mount[0] = 'N'
+	ebr.Part_correlative = -1
+	ebr.Part_id = [4]byte{'N'}
+}
+
+// Serialize escribe la estructura EBR en un archivo binario en la posición especificada.
+// Antes de escribir se valida la cadena de EBRs como quedaría con este EBR
+func (ebr *EBR) Serialize(path string, offset int64) error {
+	err := validateEBRWrite(path, ebr, offset)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Mover el puntero del archivo a la posición especificada
+	_, err = file.Seek(offset, 0)
+	if err != nil {
+		return err
+	}
+
+	// Serializar la estructura EBR directamente en el archivo
+	err = binary.Write(file, binary.LittleEndian, ebr)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Deserialize lee la estructura EBR desde un archivo binario en la posición especificada
+func (ebr *EBR) Deserialize(path string, offset int64) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Mover el puntero del archivo a la posición especificada
+	_, err = file.Seek(offset, 0)
+	if err != nil {
+		return err
+	}
+
+	// Obtener el tamaño de la estructura EBR
+	ebrSize := binary.Size(ebr)
+	if ebrSize <= 0 {
+		return fmt.Errorf("invalid EBR size: %d", ebrSize)
+	}
+
+	// Leer solo la cantidad de bytes que corresponden al tamaño de la estructura EBR
+	buffer := make([]byte, ebrSize)
+	_, err = file.Read(buffer)
+	if err != nil {
+		return err
+	}
+
+	// Deserializar los bytes leídos en la estructura EBR
+	reader := bytes.NewReader(buffer)
+	err = binary.Read(reader, binary.LittleEndian, ebr)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ToPartition convierte el EBR en una partición lógica para poder montarla y formatearla
+func (ebr *EBR) ToPartition() *Partition {
+	partition := &Partition{
+		Part_status:      [1]byte{'0'},
+		Part_type:        [1]byte{'L'},
+		Part_fit:         ebr.Part_fit,
+		Part_start:       ebr.Part_start + int32(binary.Size(EBR{})), // Los datos inician después del EBR
+		Part_size:        ebr.Part_s,
+		Part_name:        ebr.Part_name,
+		Part_correlative: -1,
+		Part_id:          [4]byte{'N'},
+	}
+
+	// Si el EBR está montado, la partición también y conserva el id guardado en el EBR
+	if ebr.Part_mount[0] == '1' {
+		partition.Part_status[0] = '1'
+		partition.Part_correlative = ebr.Part_correlative
+		partition.Part_id = ebr.Part_id
+	}
+
+	return partition
+}
+
+// GetLogicalFreeSpaces calcula los espacios libres dentro de la partición extendida a partir de la cadena de EBRs
+func GetLogicalFreeSpaces(extended *Partition, ebrs []EBR) []FreeSpace {
+	var spaces []FreeSpace
+
+	// Cada partición lógica ocupa su EBR más sus datos
+	ebrSize := int32(binary.Size(EBR{}))
+	cursor := extended.Part_start
+	for _, ebr := range ebrs {
+		// El EBR inicial vacío no ocupa espacio para una partición lógica
+		if ebr.Part_s <= 0 {
+			continue
+		}
+		if ebr.Part_start > cursor {
+			spaces = append(spaces, FreeSpace{Start: cursor, Size: ebr.Part_start - cursor})
+		}
+		cursor = ebr.Part_start + ebrSize + ebr.Part_s
+	}
+
+	// Espacio libre al final de la partición extendida
+	end := extended.Part_start + extended.Part_size
+	if end > cursor {
+		spaces = append(spaces, FreeSpace{Start: cursor, Size: end - cursor})
+	}
+
+	return spaces
 }
 
 func (ebr *EBR) PrintEBR() {
@@ -42,4 +185,6 @@ func (ebr *EBR) PrintEBR() {
 	fmt.Printf("  Part_s: %d\n", ebr.Part_s)
 	fmt.Printf("  Part_next: %d\n", ebr.Part_next)
 	fmt.Printf("  Part_name: %s\n", string(ebr.Part_name[:]))
+	fmt.Printf("  Part_correlative: %d\n", ebr.Part_correlative)
+	fmt.Printf("  Part_id: %s\n", string(ebr.Part_id[:]))
 }
\ No newline at end of file
@@ -1,307 +1,80 @@
 package structures
 
 import (
-	"backend/utils"
-	"strings"
 	"time"
 )
 
-// Crear users.txt en nuestro sistema de archivos
-func (sb *SuperBlock) CreateUsersFileExt2(path string) error {
-	// ----------- Creamos / -----------
-	// Creamos el inodo raíz
-	rootInode := &Inode{
-		I_uid:   1,
-		I_gid:   1,
-		I_size:  0,
-		I_atime: float32(time.Now().Unix()),
-		I_ctime: float32(time.Now().Unix()),
-		I_mtime: float32(time.Now().Unix()),
-		I_block: [15]int32{sb.S_blocks_count, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
-		I_type:  [1]byte{'0'},
-		I_perm:  [3]byte{'7', '7', '7'},
-	}
+// Contraseña por defecto del usuario root
+const defaultRootPassword = "123"
 
-	// Serializar el inodo raíz
-	err := rootInode.Serialize(path, int64(sb.S_first_ino))
+// initialUsersText genera el contenido inicial de users.txt con la contraseña de root cifrada
+func initialUsersText() (string, error) {
+	users := &UsersFile{}
+	users.AddGroup("root")
+	_, err := users.AddUser("root", "root", defaultRootPassword)
 	if err != nil {
-		return err
-	}
-
-	// Actualizar el bitmap de inodos
-	err = sb.UpdateBitmapInode(path)
-	if err != nil {
-		return err
-	}
-
-	// Actualizar el superbloque
-	sb.S_inodes_count++
-	sb.S_free_inodes_count--
-	sb.S_first_ino += sb.S_inode_size
-
-	// Creamos el bloque del Inodo Raíz
-	rootBlock := &FolderBlock{
-		B_content: [4]FolderContent{
-			{B_name: [12]byte{'.'}, B_inodo: 0},
-			{B_name: [12]byte{'.', '.'}, B_inodo: 0},
-			{B_name: [12]byte{'-'}, B_inodo: -1},
-			{B_name: [12]byte{'-'}, B_inodo: -1},
-		},
-	}
-
-	// Actualizar el bitmap de bloques
-	err = sb.UpdateBitmapBlock(path)
-	if err != nil {
-		return err
+		return "", err
 	}
+	return users.String(), nil
+}
 
-	// Serializar el bloque de carpeta raíz
-	err = rootBlock.Serialize(path, int64(sb.S_first_blo))
+// Crear users.txt en nuestro sistema de archivos
+func (sb *SuperBlock) CreateUsersFileExt2(path string) error {
+	// ----------- Creamos / -----------
+	err := sb.createRootFolder(path)
 	if err != nil {
 		return err
 	}
 
-	// Actualizar el superbloque
-	sb.S_blocks_count++
-	sb.S_free_blocks_count--
-	sb.S_first_blo += sb.S_block_size
-
 	// ----------- Creamos /users.txt -----------
-	usersText := "1,G,root\n1,U,root,root,123\n"
-
-	// Deserializar el inodo raíz
-	err = rootInode.Deserialize(path, int64(sb.S_inode_start+0)) // 0 porque es el inodo raíz
+	usersText, err := initialUsersText()
 	if err != nil {
 		return err
 	}
+	return sb.CreateFile(path, UsersFilePath, usersText, false, 1, 1)
+}
 
-	// Actualizamos el inodo raíz
-	rootInode.I_atime = float32(time.Now().Unix())
-
-	// Serializar el inodo raíz
-	err = rootInode.Serialize(path, int64(sb.S_inode_start+0)) // 0 porque es el inodo raíz
+// createRootFolder crea el inodo raíz y su bloque de carpeta
+func (sb *SuperBlock) createRootFolder(path string) error {
+	// Reservar el inodo y el bloque de la raíz
+	rootIndex, err := sb.AllocateInode(path)
 	if err != nil {
 		return err
 	}
-
-	// Deserializar el bloque de carpeta raíz
-	err = rootBlock.Deserialize(path, int64(sb.S_block_start+0)) // 0 porque es el bloque de carpeta raíz
+	rootBlockIndex, err := sb.AllocateBlock(path)
 	if err != nil {
 		return err
 	}
 
-	// Actualizamos el bloque de carpeta raíz
-	rootBlock.B_content[2] = FolderContent{B_name: [12]byte{'u', 's', 'e', 'r', 's', '.', 't', 'x', 't'}, B_inodo: sb.S_inodes_count}
-
-	// Serializar el bloque de carpeta raíz
-	err = rootBlock.Serialize(path, int64(sb.S_block_start+0)) // 0 porque es el bloque de carpeta raíz
-	if err != nil {
-		return err
-	}
-
-	// Creamos el inodo users.txt
-	usersInode := &Inode{
+	// Creamos el inodo raíz
+	rootInode := &Inode{
 		I_uid:   1,
 		I_gid:   1,
-		I_size:  int32(len(usersText)),
+		I_size:  0,
 		I_atime: float32(time.Now().Unix()),
 		I_ctime: float32(time.Now().Unix()),
 		I_mtime: float32(time.Now().Unix()),
-		I_block: [15]int32{sb.S_blocks_count, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
-		I_type:  [1]byte{'1'},
+		I_block: [15]int32{rootBlockIndex, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		I_type:  [1]byte{'0'},
 		I_perm:  [3]byte{'7', '7', '7'},
 	}
 
-	// Actualizar el bitmap de inodos
-	err = sb.UpdateBitmapInode(path)
-	if err != nil {
-		return err
-	}
-
-	// Serializar el inodo users.txt
-	err = usersInode.Serialize(path, int64(sb.S_first_ino))
-	if err != nil {
-		return err
-	}
-
-	// Actualizamos el superbloque
-	sb.S_inodes_count++
-	sb.S_free_inodes_count--
-	sb.S_first_ino += sb.S_inode_size
-
-	// Creamos el bloque de users.txt
-	usersBlock := &FileBlock{
-		B_content: [64]byte{},
-	}
-	// Copiamos el texto de usuarios en el bloque
-	copy(usersBlock.B_content[:], usersText)
-
-	// Serializar el bloque de users.txt
-	err = usersBlock.Serialize(path, int64(sb.S_first_blo))
-	if err != nil {
-		return err
-	}
-
-	// Actualizar el bitmap de bloques
-	err = sb.UpdateBitmapBlock(path)
+	// Serializar el inodo raíz
+	err = rootInode.Serialize(path, int64(sb.S_inode_start+(rootIndex*sb.S_inode_size)))
 	if err != nil {
 		return err
 	}
 
-	// Actualizamos el superbloque
-	sb.S_blocks_count++
-	sb.S_free_blocks_count--
-	sb.S_first_blo += sb.S_block_size
-
-	return nil
-}
-
-// createFolderInInode crea una carpeta en un inodo específico
-func (sb *SuperBlock) createFolderInInodeExt2(path string, inodeIndex int32, parentsDir []string, destDir string) error {
-	// Crear un nuevo inodo
-	inode := &Inode{}
-	// Deserializar el inodo
-	err := inode.Deserialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
-	if err != nil {
-		return err
-	}
-	// Verificar si el inodo es de tipo carpeta
-	if inode.I_type[0] == '1' {
-		return nil
+	// Creamos el bloque del Inodo Raíz
+	rootBlock := &FolderBlock{
+		B_content: [4]FolderContent{
+			{B_name: [12]byte{'.'}, B_inodo: rootIndex},
+			{B_name: [12]byte{'.', '.'}, B_inodo: rootIndex},
+			{B_name: [12]byte{'-'}, B_inodo: -1},
+			{B_name: [12]byte{'-'}, B_inodo: -1},
+		},
 	}
 
-	// Iterar sobre cada bloque del inodo (apuntadores)
-	for _, blockIndex := range inode.I_block {
-		// Si el bloque no existe, salir
-		if blockIndex == -1 {
-			break
-		}
-
-		// Crear un nuevo bloque de carpeta
-		block := &FolderBlock{}
-
-		// Deserializar el bloque
-		err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size))) // 64 porque es el tamaño de un bloque
-		if err != nil {
-			return err
-		}
-
-		// Iterar sobre cada contenido del bloque, desde el index 2 porque los primeros dos son . y ..
-		for indexContent := 2; indexContent < len(block.B_content); indexContent++ {
-			// Obtener el contenido del bloque
-			content := block.B_content[indexContent]
-
-			// Sí las carpetas padre no están vacías debereamos buscar la carpeta padre más cercana
-			if len(parentsDir) != 0 {
-				//fmt.Println("---------ESTOY  VISITANDO--------")
-
-				// Si el contenido está vacío, salir
-				if content.B_inodo == -1 {
-					break
-				}
-
-				// Obtenemos la carpeta padre más cercana
-				parentDir, err := utils.First(parentsDir)
-				if err != nil {
-					return err
-				}
-
-				// Convertir B_name a string y eliminar los caracteres nulos
-				contentName := strings.Trim(string(content.B_name[:]), "\x00 ")
-				// Convertir parentDir a string y eliminar los caracteres nulos
-				parentDirName := strings.Trim(parentDir, "\x00 ")
-				// Si el nombre del contenido coincide con el nombre de la carpeta padre
-				if strings.EqualFold(contentName, parentDirName) {
-					//fmt.Println("---------LA ENCONTRÉ-------")
-					// Si son las mismas, entonces entramos al inodo que apunta el bloque
-					err := sb.createFolderInInodeExt2(path, content.B_inodo, utils.RemoveElement(parentsDir, 0), destDir)
-					if err != nil {
-						return err
-					}
-					return nil
-				}
-			} else {
-				//fmt.Println("---------ESTOY  CREANDO--------")
-
-				// Si el apuntador al inodo está ocupado, continuar con el siguiente
-				if content.B_inodo != -1 {
-					continue
-				}
-
-				// Actualizar el contenido del bloque
-				copy(content.B_name[:], destDir)
-				content.B_inodo = sb.S_inodes_count
-
-				// Actualizar el bloque
-				block.B_content[indexContent] = content
-
-				// Serializar el bloque
-				err = block.Serialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
-				if err != nil {
-					return err
-				}
-
-				// Crear el inodo de la carpeta
-				folderInode := &Inode{
-					I_uid:   1,
-					I_gid:   1,
-					I_size:  0,
-					I_atime: float32(time.Now().Unix()),
-					I_ctime: float32(time.Now().Unix()),
-					I_mtime: float32(time.Now().Unix()),
-					I_block: [15]int32{sb.S_blocks_count, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
-					I_type:  [1]byte{'0'},
-					I_perm:  [3]byte{'6', '6', '4'},
-				}
-
-				// Serializar el inodo de la carpeta
-				err = folderInode.Serialize(path, int64(sb.S_first_ino))
-				if err != nil {
-					return err
-				}
-
-				// Actualizar el bitmap de inodos
-				err = sb.UpdateBitmapInode(path)
-				if err != nil {
-					return err
-				}
-
-				// Actualizar el superbloque
-				sb.S_inodes_count++
-				sb.S_free_inodes_count--
-				sb.S_first_ino += sb.S_inode_size
-
-				// Crear el bloque de la carpeta
-				folderBlock := &FolderBlock{
-					B_content: [4]FolderContent{
-						{B_name: [12]byte{'.'}, B_inodo: content.B_inodo},
-						{B_name: [12]byte{'.', '.'}, B_inodo: inodeIndex},
-						{B_name: [12]byte{'-'}, B_inodo: -1},
-						{B_name: [12]byte{'-'}, B_inodo: -1},
-					},
-				}
-
-				// Serializar el bloque de la carpeta
-				err = folderBlock.Serialize(path, int64(sb.S_first_blo))
-				if err != nil {
-					return err
-				}
-
-				// Actualizar el bitmap de bloques
-				err = sb.UpdateBitmapBlock(path)
-				if err != nil {
-					return err
-				}
-
-				// Actualizar el superbloque
-				sb.S_blocks_count++
-				sb.S_free_blocks_count--
-				sb.S_first_blo += sb.S_block_size
-
-				return nil
-			}
-		}
-
-	}
-	return nil
+	// Serializar el bloque de carpeta raíz
+	return rootBlock.Serialize(path, int64(sb.S_block_start+(rootBlockIndex*sb.S_block_size)))
 }
\ No newline at end of file
@@ -0,0 +1,582 @@
+package structures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Cantidad de apuntadores directos de un inodo
+const directBlocks = 12
+
+// CreateFile crea un archivo con el contenido indicado en la ruta filePath.
+// Si createParents es verdadero, se crean las carpetas padre que no existan
+func (sb *SuperBlock) CreateFile(path string, filePath string, content string, createParents bool, uid int32, gid int32) error {
+	components, err := SplitPath(filePath)
+	if err != nil {
+		return err
+	}
+	if len(components) == 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidPath, filePath)
+	}
+	err = ValidateName(components[len(components)-1])
+	if err != nil {
+		return err
+	}
+
+	// Calcular los bloques necesarios para el contenido
+	blocksNeeded := (int32(len(content)) + sb.S_block_size - 1) / sb.S_block_size
+	if blocksNeeded > MaxFileBlocks {
+		return fmt.Errorf("el archivo excede el tamaño máximo soportado (%d bytes)", MaxFileBlocks*sb.S_block_size)
+	}
+
+	// Verificar el espacio (incluyendo los bloques de apuntadores) y buscar o crear la carpeta padre
+	parentIndex, created, err := sb.prepareEntry(path, components, BlocksRequired(blocksNeeded), createParents, uid, gid)
+	if err != nil {
+		return err
+	}
+
+	// Si el archivo no se puede crear se eliminan también las carpetas padre creadas para él
+	err = sb.createFileIn(path, parentIndex, components[len(components)-1], content, uid, gid)
+	if err != nil {
+		return errors.Join(err, sb.discardFolders(path, created))
+	}
+
+	return nil
+}
+
+// createFileIn crea un archivo con el contenido indicado dentro de la carpeta parentIndex.
+// El llamador verifica antes que haya espacio (ver prepareEntry)
+func (sb *SuperBlock) createFileIn(path string, parentIndex int32, name string, content string, uid int32, gid int32) error {
+	// Reservar el inodo del archivo
+	fileIndex, err := sb.AllocateInode(path)
+	if err != nil {
+		return err
+	}
+
+	// Crear el inodo del archivo
+	fileInode := &Inode{
+		I_uid:   uid,
+		I_gid:   gid,
+		I_size:  int32(len(content)),
+		I_atime: float32(time.Now().Unix()),
+		I_ctime: float32(time.Now().Unix()),
+		I_mtime: float32(time.Now().Unix()),
+		I_block: [15]int32{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		I_type:  [1]byte{'1'},
+		I_perm:  [3]byte{'6', '6', '4'},
+	}
+
+	// Escribir el contenido y registrar el archivo en la carpeta padre;
+	// si algo falla se liberan el inodo y los bloques ya reservados
+	err = sb.writeNewFile(path, fileIndex, fileInode, content, parentIndex, name)
+	if err != nil {
+		return errors.Join(err, sb.discardInode(path, fileIndex, fileInode))
+	}
+
+	return nil
+}
+
+// writeNewFile escribe el contenido y el inodo de un archivo nuevo y lo agrega a la carpeta parentIndex
+func (sb *SuperBlock) writeNewFile(path string, fileIndex int32, fileInode *Inode, content string, parentIndex int32, name string) error {
+	blocksNeeded := (int32(len(content)) + sb.S_block_size - 1) / sb.S_block_size
+	for i := int32(0); i < blocksNeeded; i++ {
+		// Obtener el bloque físico, creando los bloques de apuntadores necesarios
+		blockIndex, err := sb.MapBlock(path, fileInode, i, true)
+		if err != nil {
+			return err
+		}
+
+		fileBlock := &FileBlock{}
+		copy(fileBlock.B_content[:], content[i*sb.S_block_size:])
+
+		// Serializar el bloque de archivo
+		err = fileBlock.Serialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Guardar los apuntadores a los bloques en el inodo
+	err := fileInode.Serialize(path, int64(sb.S_inode_start+(fileIndex*sb.S_inode_size)))
+	if err != nil {
+		return err
+	}
+
+	// La entrada se agrega al final para que la carpeta solo apunte a archivos completos
+	return sb.addFolderEntry(path, parentIndex, name, fileIndex)
+}
+
+// discardInode libera los bloques y el inodo de un archivo o carpeta que no se pudo crear
+func (sb *SuperBlock) discardInode(path string, inodeIndex int32, inode *Inode) error {
+	err := sb.TruncateBlocks(path, inode, 0)
+	if err != nil {
+		return fmt.Errorf("error al liberar los bloques del inodo %d: %w", inodeIndex, err)
+	}
+	err = sb.FreeInode(path, inodeIndex)
+	if err != nil {
+		return fmt.Errorf("error al liberar el inodo %d: %w", inodeIndex, err)
+	}
+	return nil
+}
+
+// discardFolders elimina las carpetas padre creadas por prepareEntry, ordenadas de la más externa
+// a la más interna. Cada una solo contiene a la siguiente, así que basta con quitar la primera
+// de su carpeta padre y liberar todas
+func (sb *SuperBlock) discardFolders(path string, created []int32) error {
+	if len(created) == 0 {
+		return nil
+	}
+
+	first, err := sb.readInode(path, created[0])
+	if err != nil {
+		return err
+	}
+	parentIndex, err := sb.parentOf(path, first, created[0])
+	if err != nil {
+		return err
+	}
+	err = sb.removeFolderEntry(path, parentIndex, created[0])
+	if err != nil {
+		return err
+	}
+
+	for i := len(created) - 1; i >= 0; i-- {
+		folder, err := sb.readInode(path, created[i])
+		if err != nil {
+			return err
+		}
+		err = sb.discardInode(path, created[i], folder)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeFolderEntry quita de la carpeta folderIndex la entrada que apunta al inodo inodeIndex
+func (sb *SuperBlock) removeFolderEntry(path string, folderIndex int32, inodeIndex int32) error {
+	folderInode, err := sb.readInode(path, folderIndex)
+	if err != nil {
+		return err
+	}
+
+	blocks, err := sb.folderBlocks(path, folderInode)
+	if err != nil {
+		return err
+	}
+
+	for _, blockIndex := range blocks {
+		block := &FolderBlock{}
+		err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
+		if err != nil {
+			return err
+		}
+
+		for i, content := range block.B_content {
+			name := content.Name()
+			if content.B_inodo != inodeIndex || name == "." || name == ".." {
+				continue
+			}
+
+			// Dejar la entrada libre
+			block.B_content[i] = FolderContent{B_name: [12]byte{'-'}, B_inodo: -1}
+			return block.Serialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
+		}
+	}
+
+	return fmt.Errorf("%w: inodo %d en la carpeta %d", ErrNotFound, inodeIndex, folderIndex)
+}
+
+// WriteFileContent reemplaza el contenido del archivo inodeIndex, reservando los bloques que
+// falten y liberando los que sobren
+func (sb *SuperBlock) WriteFileContent(path string, inodeIndex int32, inode *Inode, content string) error {
+	if inode.I_type[0] != '1' {
+		return errors.New("el inodo no es un archivo")
+	}
+
+	// Calcular los bloques necesarios para el nuevo contenido
+	blocksNeeded := (int32(len(content)) + sb.S_block_size - 1) / sb.S_block_size
+	if blocksNeeded > MaxFileBlocks {
+		return fmt.Errorf("el archivo excede el tamaño máximo soportado (%d bytes)", MaxFileBlocks*sb.S_block_size)
+	}
+
+	// Verificar que haya bloques libres para lo que crece el archivo
+	currentBlocks := (inode.I_size + sb.S_block_size - 1) / sb.S_block_size
+	if extra := BlocksRequired(blocksNeeded) - BlocksRequired(currentBlocks); extra > sb.S_free_blocks_count {
+		return errors.New("no hay espacio suficiente en la partición")
+	}
+
+	// Escribir el contenido reutilizando los bloques que ya tiene el archivo
+	for i := int32(0); i < blocksNeeded; i++ {
+		blockIndex, err := sb.MapBlock(path, inode, i, true)
+		if err != nil {
+			return err
+		}
+
+		fileBlock := &FileBlock{}
+		copy(fileBlock.B_content[:], content[i*sb.S_block_size:])
+
+		err = fileBlock.Serialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Liberar los bloques que ya no se usan
+	err := sb.TruncateBlocks(path, inode, blocksNeeded)
+	if err != nil {
+		return err
+	}
+
+	// Actualizar el tamaño y la fecha de modificación
+	inode.I_size = int32(len(content))
+	inode.I_mtime = float32(time.Now().Unix())
+	return inode.Serialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
+}
+
+// ReadFileContent obtiene el contenido de un archivo recorriendo sus bloques directos e indirectos hasta I_size
+func (sb *SuperBlock) ReadFileContent(path string, inode *Inode) (string, error) {
+	if inode.I_type[0] != '1' {
+		return "", errors.New("el inodo no es un archivo")
+	}
+
+	// Recorrer los bloques lógicos del archivo en orden
+	var content strings.Builder
+	blocksCount := (inode.I_size + sb.S_block_size - 1) / sb.S_block_size
+	for i := int32(0); i < blocksCount; i++ {
+		blockIndex, err := sb.MapBlock(path, inode, i, false)
+		if err != nil {
+			return "", err
+		}
+
+		// Un bloque sin asignar se lee como ceros
+		block := &FileBlock{}
+		if blockIndex != -1 {
+			err = block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
+			if err != nil {
+				return "", err
+			}
+		}
+		content.Write(block.B_content[:])
+	}
+
+	// Recortar el contenido al tamaño del archivo
+	data := content.String()
+	if int32(len(data)) > inode.I_size {
+		data = data[:inode.I_size]
+	}
+
+	return data, nil
+}
+
+// lookupFolders recorre desde la raíz las carpetas de components que ya existen.
+// Devuelve el índice de la última carpeta encontrada y cuántos componentes existen
+func (sb *SuperBlock) lookupFolders(path string, components []string) (int32, int, error) {
+	folderIndex := int32(0)
+	for i, component := range components {
+		childIndex, err := sb.LookupChild(path, folderIndex, component)
+		if errors.Is(err, ErrNotFound) {
+			return folderIndex, i, nil
+		}
+		if errors.Is(err, ErrNotDirectory) {
+			return -1, 0, fmt.Errorf("%w: /%s", ErrNotDirectory, strings.Join(components[:i], "/"))
+		}
+		if err != nil {
+			return -1, 0, err
+		}
+		folderIndex = childIndex
+	}
+
+	// La última carpeta del recorrido también debe ser una carpeta
+	folder, err := sb.readInode(path, folderIndex)
+	if err != nil {
+		return -1, 0, err
+	}
+	if folder.I_type[0] != '0' {
+		return -1, 0, fmt.Errorf("%w: /%s", ErrNotDirectory, strings.Join(components, "/"))
+	}
+
+	return folderIndex, len(components), nil
+}
+
+// prepareEntry verifica que se pueda crear la ruta components con un inodo y dataBlocks bloques propios,
+// y devuelve el índice de su carpeta padre. Si createParents es verdadero, crea las carpetas padre que falten
+// y devuelve también sus índices para que el llamador pueda eliminarlas si la entrada final falla.
+// El espacio se verifica antes de reservar nada para no dejar la partición a medias
+func (sb *SuperBlock) prepareEntry(path string, components []string, dataBlocks int32, createParents bool, uid int32, gid int32) (int32, []int32, error) {
+	parents := components[:len(components)-1]
+	folderIndex, found, err := sb.lookupFolders(path, parents)
+	if err != nil {
+		return -1, nil, err
+	}
+
+	missing := parents[found:]
+	if len(missing) > 0 {
+		if !createParents {
+			return -1, nil, fmt.Errorf("%w: /%s", ErrParentNotFound, strings.Join(parents[:found+1], "/"))
+		}
+		for _, name := range missing {
+			err = ValidateName(name)
+			if err != nil {
+				return -1, nil, err
+			}
+		}
+	} else {
+		// Verificar que el archivo o carpeta no exista
+		existing, err := sb.findInFolder(path, folderIndex, components[len(components)-1])
+		if err != nil {
+			return -1, nil, err
+		}
+		if existing != -1 {
+			return -1, nil, fmt.Errorf("%w: /%s", ErrAlreadyExists, strings.Join(components, "/"))
+		}
+	}
+
+	// Cada carpeta que falta usa un inodo y un bloque, y su primer bloque tiene lugar para la siguiente entrada.
+	// Solo la carpeta existente más profunda puede necesitar un bloque más para la nueva entrada
+	entryBlocks, err := sb.entryBlocksNeeded(path, folderIndex)
+	if err != nil {
+		return -1, nil, err
+	}
+	inodesNeeded := int32(len(missing)) + 1
+	blocksNeeded := int32(len(missing)) + dataBlocks + entryBlocks
+	if sb.S_free_inodes_count < inodesNeeded || sb.S_free_blocks_count < blocksNeeded {
+		return -1, nil, errors.New("no hay espacio suficiente en la partición")
+	}
+
+	// Crear las carpetas que faltan
+	var created []int32
+	for _, name := range missing {
+		folderIndex, err = sb.createFolderIn(path, folderIndex, name, uid, gid)
+		if err != nil {
+			return -1, nil, errors.Join(err, sb.discardFolders(path, created))
+		}
+		created = append(created, folderIndex)
+	}
+
+	return folderIndex, created, nil
+}
+
+// entryBlocksNeeded obtiene los bloques que necesita la carpeta folderIndex para agregar una entrada:
+// 0 si tiene una entrada libre, o el nuevo bloque de carpeta y sus bloques de apuntadores si está llena
+func (sb *SuperBlock) entryBlocksNeeded(path string, folderIndex int32) (int32, error) {
+	folderInode, err := sb.readInode(path, folderIndex)
+	if err != nil {
+		return -1, err
+	}
+
+	blocks, err := sb.folderBlocks(path, folderInode)
+	if err != nil {
+		return -1, err
+	}
+
+	for _, blockIndex := range blocks {
+		block := &FolderBlock{}
+		err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
+		if err != nil {
+			return -1, err
+		}
+		for _, content := range block.B_content {
+			if content.B_inodo == -1 {
+				return 0, nil
+			}
+		}
+	}
+
+	count := int32(len(blocks))
+	return BlocksRequired(count+1) - BlocksRequired(count), nil
+}
+
+// findInFolder busca una entrada por nombre exacto (distingue mayúsculas) en la carpeta indicada y devuelve su inodo, o -1 si no existe
+func (sb *SuperBlock) findInFolder(path string, folderIndex int32, name string) (int32, error) {
+	folderInode := &Inode{}
+	err := folderInode.Deserialize(path, int64(sb.S_inode_start+(folderIndex*sb.S_inode_size)))
+	if err != nil {
+		return -1, err
+	}
+
+	// Obtener los bloques de la carpeta
+	blocks, err := sb.folderBlocks(path, folderInode)
+	if err != nil {
+		return -1, err
+	}
+
+	for _, blockIndex := range blocks {
+		block := &FolderBlock{}
+		err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
+		if err != nil {
+			return -1, err
+		}
+
+		for _, content := range block.B_content {
+			if content.B_inodo == -1 {
+				continue
+			}
+
+			// Convertir B_name a string y eliminar los caracteres nulos
+			contentName := content.Name()
+			if contentName == "." || contentName == ".." {
+				continue
+			}
+			if contentName == name {
+				return content.B_inodo, nil
+			}
+		}
+	}
+
+	return -1, nil
+}
+
+// addFolderEntry agrega una entrada con el nombre y el inodo indicados en la carpeta folderIndex
+func (sb *SuperBlock) addFolderEntry(path string, folderIndex int32, name string, inodeIndex int32) error {
+	folderInode := &Inode{}
+	err := folderInode.Deserialize(path, int64(sb.S_inode_start+(folderIndex*sb.S_inode_size)))
+	if err != nil {
+		return err
+	}
+
+	// Obtener los bloques de la carpeta
+	blocks, err := sb.folderBlocks(path, folderInode)
+	if err != nil {
+		return err
+	}
+
+	// Buscar una entrada libre en los bloques de la carpeta
+	for _, blockIndex := range blocks {
+		block := &FolderBlock{}
+		err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
+		if err != nil {
+			return err
+		}
+
+		for indexContent := range block.B_content {
+			if block.B_content[indexContent].B_inodo != -1 {
+				continue
+			}
+
+			// Actualizar el contenido del bloque
+			content := FolderContent{B_inodo: inodeIndex}
+			copy(content.B_name[:], name)
+			block.B_content[indexContent] = content
+
+			// Serializar el bloque
+			err = block.Serialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
+			if err != nil {
+				return err
+			}
+
+			// Actualizar la fecha de modificación de la carpeta
+			folderInode.I_mtime = float32(time.Now().Unix())
+			return folderInode.Serialize(path, int64(sb.S_inode_start+(folderIndex*sb.S_inode_size)))
+		}
+	}
+
+	// Todos los bloques están llenos: agregar un nuevo bloque de carpeta.
+	// Solo el primer bloque reserva las entradas . y .., el resto queda libre
+	if sb.S_free_blocks_count < BlocksRequired(int32(len(blocks))+1)-BlocksRequired(int32(len(blocks))) {
+		return errors.New("no hay espacio suficiente en la partición")
+	}
+	blockIndex, err := sb.MapBlock(path, folderInode, int32(len(blocks)), true)
+	if err != nil {
+		return err
+	}
+
+	block := &FolderBlock{}
+	for i := range block.B_content {
+		block.B_content[i] = FolderContent{B_name: [12]byte{'-'}, B_inodo: -1}
+	}
+	block.B_content[0] = FolderContent{B_inodo: inodeIndex}
+	copy(block.B_content[0].B_name[:], name)
+
+	// Serializar el nuevo bloque
+	err = block.Serialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
+	if err != nil {
+		return err
+	}
+
+	// Guardar el nuevo apuntador y la fecha de modificación de la carpeta
+	folderInode.I_mtime = float32(time.Now().Unix())
+	return folderInode.Serialize(path, int64(sb.S_inode_start+(folderIndex*sb.S_inode_size)))
+}
+
+// folderBlocks obtiene los bloques de una carpeta en orden, hasta el primer bloque lógico sin asignar
+func (sb *SuperBlock) folderBlocks(path string, folderInode *Inode) ([]int32, error) {
+	var blocks []int32
+	for i := int32(0); i < MaxFileBlocks; i++ {
+		blockIndex, err := sb.MapBlock(path, folderInode, i, false)
+		if err != nil {
+			return nil, err
+		}
+		if blockIndex == -1 {
+			break
+		}
+		blocks = append(blocks, blockIndex)
+	}
+	return blocks, nil
+}
+
+// createFolderIn crea una carpeta con el nombre indicado dentro de la carpeta parentIndex y devuelve su inodo.
+// El llamador verifica antes que haya espacio (ver prepareEntry)
+func (sb *SuperBlock) createFolderIn(path string, parentIndex int32, name string, uid int32, gid int32) (int32, error) {
+	// Reservar el inodo y el bloque de la carpeta
+	folderIndex, err := sb.AllocateInode(path)
+	if err != nil {
+		return -1, err
+	}
+	blockIndex, err := sb.AllocateBlock(path)
+	if err != nil {
+		return -1, errors.Join(err, sb.FreeInode(path, folderIndex))
+	}
+
+	// Crear el inodo de la carpeta
+	folderInode := &Inode{
+		I_uid:   uid,
+		I_gid:   gid,
+		I_size:  0,
+		I_atime: float32(time.Now().Unix()),
+		I_ctime: float32(time.Now().Unix()),
+		I_mtime: float32(time.Now().Unix()),
+		I_block: [15]int32{blockIndex, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		I_type:  [1]byte{'0'},
+		I_perm:  [3]byte{'6', '6', '4'},
+	}
+
+	// Escribir la carpeta y registrarla en la carpeta padre;
+	// si algo falla se liberan el inodo y el bloque reservados
+	err = sb.writeNewFolder(path, folderIndex, folderInode, parentIndex, name)
+	if err != nil {
+		return -1, errors.Join(err, sb.discardInode(path, folderIndex, folderInode))
+	}
+
+	return folderIndex, nil
+}
+
+// writeNewFolder escribe el inodo y el primer bloque de una carpeta nueva y la agrega a la carpeta parentIndex
+func (sb *SuperBlock) writeNewFolder(path string, folderIndex int32, folderInode *Inode, parentIndex int32, name string) error {
+	// Serializar el inodo de la carpeta
+	err := folderInode.Serialize(path, int64(sb.S_inode_start+(folderIndex*sb.S_inode_size)))
+	if err != nil {
+		return err
+	}
+
+	// Crear el bloque de la carpeta
+	folderBlock := &FolderBlock{
+		B_content: [4]FolderContent{
+			{B_name: [12]byte{'.'}, B_inodo: folderIndex},
+			{B_name: [12]byte{'.', '.'}, B_inodo: parentIndex},
+			{B_name: [12]byte{'-'}, B_inodo: -1},
+			{B_name: [12]byte{'-'}, B_inodo: -1},
+		},
+	}
+
+	// Serializar el bloque de la carpeta
+	err = folderBlock.Serialize(path, int64(sb.S_block_start+(folderInode.I_block[0]*sb.S_block_size)))
+	if err != nil {
+		return err
+	}
+
+	// La entrada se agrega al final para que la carpeta padre solo apunte a carpetas completas
+	return sb.addFolderEntry(path, parentIndex, name, folderIndex)
+}
\ No newline at end of file
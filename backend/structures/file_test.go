@@ -0,0 +1,93 @@
+package structures
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCreateFolderCountsNewParentBlock(t *testing.T) {
+	sb, path := newTestFileSystem(t, 16)
+
+	// La raíz tiene ., .., users.txt y una entrada libre: /a la llena
+	if err := sb.CreateFolder(path, "/a", false, 1, 1); err != nil {
+		t.Fatalf("mkdir /a: %v", err)
+	}
+
+	// /b necesita su bloque y un nuevo bloque de carpeta en la raíz
+	freeInodes, freeBlocks := sb.S_free_inodes_count, int32(1)
+	sb.S_free_blocks_count = freeBlocks
+	if err := sb.CreateFolder(path, "/b", false, 1, 1); err == nil {
+		t.Fatal("mkdir /b con un solo bloque libre debería fallar")
+	}
+	if sb.S_free_inodes_count != freeInodes || sb.S_free_blocks_count != freeBlocks {
+		t.Fatalf("el fallo reservó espacio: inodos %d->%d, bloques %d->%d",
+			freeInodes, sb.S_free_inodes_count, freeBlocks, sb.S_free_blocks_count)
+	}
+	if _, _, err := sb.ResolvePath(path, "/b"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("ResolvePath /b = %v; se esperaba ErrNotFound", err)
+	}
+
+	sb.S_free_blocks_count = 2
+	if err := sb.CreateFolder(path, "/b", false, 1, 1); err != nil {
+		t.Fatalf("mkdir /b con dos bloques libres: %v", err)
+	}
+	if sb.S_free_blocks_count != 0 {
+		t.Fatalf("quedaron %d bloques libres; se esperaban 0", sb.S_free_blocks_count)
+	}
+}
+
+func TestCreateFileWithParentsChecksSpaceFirst(t *testing.T) {
+	sb, path := newTestFileSystem(t, 16)
+
+	// /p, /p/q y el archivo necesitan tres inodos
+	sb.S_free_inodes_count = 2
+	if err := sb.CreateFile(path, "/p/q/archivo.txt", "contenido", true, 1, 1); err == nil {
+		t.Fatal("mkfile -r con dos inodos libres debería fallar")
+	}
+	if _, _, err := sb.ResolvePath(path, "/p"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("ResolvePath /p = %v; no se debían crear carpetas padre", err)
+	}
+
+	sb.S_free_inodes_count = 3
+	if err := sb.CreateFile(path, "/p/q/archivo.txt", "contenido", true, 1, 1); err != nil {
+		t.Fatalf("mkfile -r con tres inodos libres: %v", err)
+	}
+	_, inode, err := sb.ResolvePath(path, "/p/q/archivo.txt")
+	if err != nil {
+		t.Fatalf("ResolvePath /p/q/archivo.txt: %v", err)
+	}
+	content, err := sb.ReadFileContent(path, inode)
+	if err != nil || content != "contenido" {
+		t.Fatalf("ReadFileContent = %q, %v", content, err)
+	}
+}
+
+func TestDiscardFoldersUndoesCreatedParents(t *testing.T) {
+	sb, path := newTestFileSystem(t, 16)
+	freeInodes, freeBlocks := sb.S_free_inodes_count, sb.S_free_blocks_count
+
+	// Crear /p y /p/q como carpetas padre de /p/q/archivo.txt y luego deshacerlas
+	parentIndex, created, err := sb.prepareEntry(path, []string{"p", "q", "archivo.txt"}, 1, true, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 2 || created[1] != parentIndex {
+		t.Fatalf("carpetas creadas = %v, padre %d", created, parentIndex)
+	}
+	if err := sb.discardFolders(path, created); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := sb.ResolvePath(path, "/p"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("ResolvePath /p = %v; se esperaba ErrNotFound", err)
+	}
+	if sb.S_free_inodes_count != freeInodes || sb.S_free_blocks_count != freeBlocks {
+		t.Fatalf("no se liberó el espacio: inodos %d->%d, bloques %d->%d",
+			freeInodes, sb.S_free_inodes_count, freeBlocks, sb.S_free_blocks_count)
+	}
+
+	// La entrada liberada de la raíz se puede volver a usar
+	if err := sb.CreateFolder(path, "/p", false, 1, 1); err != nil {
+		t.Fatalf("mkdir /p después de deshacer: %v", err)
+	}
+}
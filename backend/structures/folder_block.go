@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"os"
+	"strings"
 )
 
 type FolderBlock struct {
@@ -78,10 +79,15 @@ func (fb *FolderBlock) Deserialize(path string, offset int64) error {
 	return nil
 }
 
+// Name devuelve el nombre de la entrada sin los caracteres nulos de relleno
+func (fc *FolderContent) Name() string {
+	return strings.TrimRight(string(fc.B_name[:]), "\x00")
+}
+
 // Print imprime los atributos del bloque de carpeta
 func (fb *FolderBlock) Print() {
 	for i, content := range fb.B_content {
-		name := string(content.B_name[:])
+		name := content.Name()
 		fmt.Printf("Content %d:\n", i+1)
 		fmt.Printf("  B_name: %s\n", name)
 		fmt.Printf("  B_inodo: %d\n", content.B_inodo)
@@ -0,0 +1,37 @@
+package structures
+
+// FreeSpace representa un espacio libre contiguo dentro del disco o de una partición extendida
+type FreeSpace struct {
+	Start int32 // Byte de inicio del espacio libre
+	Size  int32 // Tamaño del espacio libre en bytes
+}
+
+// SelectFreeSpace escoge el espacio libre donde cabe el tamaño solicitado según el ajuste (F, B o W)
+func SelectFreeSpace(spaces []FreeSpace, size int32, fit byte) *FreeSpace {
+	var selected *FreeSpace
+
+	for i := range spaces {
+		// Descartar los espacios donde no cabe el tamaño solicitado
+		if spaces[i].Size < size {
+			continue
+		}
+
+		switch fit {
+		case 'B':
+			// Mejor ajuste: el espacio más pequeño donde quepa
+			if selected == nil || spaces[i].Size < selected.Size {
+				selected = &spaces[i]
+			}
+		case 'W':
+			// Peor ajuste: el espacio más grande disponible
+			if selected == nil || spaces[i].Size > selected.Size {
+				selected = &spaces[i]
+			}
+		default:
+			// Primer ajuste: el primer espacio donde quepa
+			return &spaces[i]
+		}
+	}
+
+	return selected
+}
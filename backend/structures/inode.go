@@ -96,4 +96,17 @@ func (inode *Inode) Print() {
 	fmt.Printf("I_block: %v\n", inode.I_block)
 	fmt.Printf("I_type: %s\n", string(inode.I_type[:]))
 	fmt.Printf("I_perm: %s\n", string(inode.I_perm[:]))
+}
+
+// HasPermission verifica si el usuario (uid, gid) tiene el permiso indicado (4 lectura, 2 escritura, 1 ejecución)
+// según los permisos del propietario, del grupo o de otros usuarios
+func (inode *Inode) HasPermission(uid int32, gid int32, permission byte) bool {
+	perm := inode.I_perm[2] // Otros usuarios
+	if inode.I_uid == uid {
+		perm = inode.I_perm[0] // Propietario
+	} else if inode.I_gid == gid {
+		perm = inode.I_perm[1] // Grupo
+	}
+
+	return (perm-'0')&permission != 0
 }
\ No newline at end of file
@@ -2,6 +2,7 @@ package structures
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -53,6 +54,7 @@ func (journal *Journal) Deserialize(path string, offset int64) error {
 	if err != nil {
 		return err
 	}
+	defer file.Close()
 
 	// Mover el puntero del archivo a la posición especificada
 	_, err = file.Seek(offset, 0)
@@ -69,6 +71,45 @@ func (journal *Journal) Deserialize(path string, offset int64) error {
 	return nil
 }
 
+// JournalStart obtiene la posición del journaling, que está entre el superbloque y el bitmap de inodos
+func (sb *SuperBlock) JournalStart() int64 {
+	totalInodes := int64(sb.TotalInodes())
+	return int64(sb.S_bm_inode_start) - int64(binary.Size(Journal{}))*totalInodes
+}
+
+// AddJournal registra una operación en la primera entrada libre del journaling (solo EXT3)
+func (sb *SuperBlock) AddJournal(path string, operation string, filePath string, content string) error {
+	journalStart := sb.JournalStart()
+	totalEntries := sb.TotalInodes()
+
+	// Buscar la primera entrada libre del journaling
+	for i := int32(0); i < totalEntries; i++ {
+		entry := &Journal{}
+		err := entry.Deserialize(path, journalStart+int64(binary.Size(Journal{}))*int64(i))
+		if err != nil {
+			return err
+		}
+		if entry.J_content.I_operation[0] != 0 {
+			continue
+		}
+
+		journal := &Journal{
+			J_count: i,
+			J_content: Information{
+				I_date: float32(time.Now().Unix()),
+			},
+		}
+		copy(journal.J_content.I_operation[:], operation)
+		copy(journal.J_content.I_path[:], filePath)
+		copy(journal.J_content.I_content[:], content)
+
+		// Serializar el journal
+		return journal.Serialize(path, journalStart)
+	}
+
+	return errors.New("el journaling está lleno")
+}
+
 // PrintJournal imprime en consola la estructura Journal
 func (journal *Journal) Print() {
 	// Convertir el tiempo de montaje a una fecha
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt" // Paquete para formateo de E/S
 	"os"  // Paquete para funciones del sistema operativo
+	"sort"
 	"strings"
 	"time"
 )
@@ -18,8 +19,66 @@ type MBR struct {
 	Mbr_partitions     [4]Partition // Particiones del MBR
 }
 
+// CheckDiskImage verifica que el archivo sea una imagen de disco creada con mkdisk:
+// la firma y el ajuste del MBR deben ser válidos y el tamaño debe coincidir con el del archivo
+func (mbr *MBR) CheckDiskImage(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	// El archivo debe poder contener al menos el MBR
+	if info.Size() < int64(binary.Size(MBR{})) {
+		return errors.New("el archivo no es un disco válido: es más pequeño que el MBR")
+	}
+
+	err = mbr.Deserialize(path)
+	if err != nil {
+		return err
+	}
+
+	// La firma se genera con un número aleatorio no negativo
+	if mbr.Mbr_disk_signature <= 0 {
+		return errors.New("el archivo no es un disco válido: firma del MBR inválida")
+	}
+
+	// El ajuste del disco solo puede ser B, F o W
+	if fit := mbr.Mbr_disk_fit[0]; fit != 'B' && fit != 'F' && fit != 'W' {
+		return errors.New("el archivo no es un disco válido: ajuste del MBR inválido")
+	}
+
+	// El tamaño registrado en el MBR debe ser el tamaño del archivo
+	if int64(mbr.Mbr_size) != info.Size() {
+		return fmt.Errorf("el archivo no es un disco válido: el MBR indica %d bytes y el archivo tiene %d", mbr.Mbr_size, info.Size())
+	}
+
+	return nil
+}
+
 // SerializeMBR escribe la estructura MBR al inicio de un archivo binario
 func (mbr *MBR) Serialize(path string) error {
+	// Validar las invariantes de la tabla de particiones antes de escribir
+	err := checkTableWrite(path, mbr.Validate(path))
+	if err != nil {
+		return err
+	}
+
+	return mbr.write(path)
+}
+
+// SerializeWithChain escribe el MBR validándolo con la cadena de EBRs indicada en lugar de la del disco.
+// Se usa cuando la cadena todavía no está escrita, como al crear la partición extendida
+func (mbr *MBR) SerializeWithChain(path string, ebrs []EBR) error {
+	err := checkTableWrite(path, ValidatePartitionTable(mbr, ebrs))
+	if err != nil {
+		return err
+	}
+
+	return mbr.write(path)
+}
+
+// write escribe el MBR al inicio del disco sin validarlo
+func (mbr *MBR) write(path string) error {
 	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0644)
 	if err != nil {
 		return err
@@ -66,27 +125,67 @@ func (mbr *MBR) Deserialize(path string) error {
 	return nil
 }
 
-// Método para obtener la primera partición disponible
-func (mbr *MBR) GetFirstAvailablePartition() (*Partition, int, int) {
-	// Calcular el offset para el start de la partición
-	offset := binary.Size(mbr) // Tamaño del MBR en bytes
+// GetFreeSpaces calcula los espacios libres del disco entre las particiones del MBR
+func (mbr *MBR) GetFreeSpaces() []FreeSpace {
+	// Obtener las particiones ocupadas ordenadas por su byte de inicio
+	var used []Partition
+	for _, partition := range mbr.Mbr_partitions {
+		if partition.Part_start != -1 {
+			used = append(used, partition)
+		}
+	}
+	sort.Slice(used, func(i, j int) bool {
+		return used[i].Part_start < used[j].Part_start
+	})
+
+	var spaces []FreeSpace
 
-	// Recorrer las particiones del MBR
+	// El primer byte disponible está después del MBR
+	cursor := int32(binary.Size(mbr))
+	for _, partition := range used {
+		if partition.Part_start > cursor {
+			spaces = append(spaces, FreeSpace{Start: cursor, Size: partition.Part_start - cursor})
+		}
+		if end := partition.Part_start + partition.Part_size; end > cursor {
+			cursor = end
+		}
+	}
+
+	// Espacio libre al final del disco
+	if mbr.Mbr_size > cursor {
+		spaces = append(spaces, FreeSpace{Start: cursor, Size: mbr.Mbr_size - cursor})
+	}
+
+	return spaces
+}
+
+// GetAvailablePartition obtiene una entrada libre del MBR y el byte de inicio según el ajuste del disco
+func (mbr *MBR) GetAvailablePartition(sizeBytes int) (*Partition, int, int, error) {
+	// Buscar la primera entrada libre del MBR
+	index := -1
 	for i := 0; i < len(mbr.Mbr_partitions); i++ {
 		// Si el start de la partición es -1, entonces está disponible
 		if mbr.Mbr_partitions[i].Part_start == -1 {
-			// Devolver la partición, el offset y el índice
-			return &mbr.Mbr_partitions[i], offset, i
-		} else {
-			// Calcular el nuevo offset para la siguiente partición, es decir, sumar el tamaño de la partición
-			offset += int(mbr.Mbr_partitions[i].Part_size)
+			index = i
+			break
 		}
 	}
-	return nil, -1, -1
+	if index == -1 {
+		return nil, -1, -1, errors.New("no hay particiones disponibles en el disco")
+	}
+
+	// Buscar el espacio libre según el ajuste del disco (FF, BF o WF)
+	space := SelectFreeSpace(mbr.GetFreeSpaces(), int32(sizeBytes), mbr.Mbr_disk_fit[0])
+	if space == nil {
+		return nil, -1, -1, errors.New("no hay espacio suficiente en el disco para la partición")
+	}
+
+	// Devolver la partición, el offset y el índice
+	return &mbr.Mbr_partitions[index], int(space.Start), index, nil
 }
 
-// Método para obtener una partición por nombre
-func (mbr *MBR) GetPartitionByName(name string) (*Partition, int) {
+// Método para obtener una partición por nombre, incluyendo las particiones lógicas (índice -1)
+func (mbr *MBR) GetPartitionByName(name string, path string) (*Partition, int) {
 	// Recorrer las particiones del MBR
 	for i, partition := range mbr.Mbr_partitions {
 		// Convertir Part_name a string y eliminar los caracteres nulos
@@ -98,6 +197,115 @@ func (mbr *MBR) GetPartitionByName(name string) (*Partition, int) {
 			return &partition, i
 		}
 	}
+
+	// Buscar entre las particiones lógicas de la partición extendida
+	ebr, err := mbr.GetLogicalPartitionByName(name, path)
+	if err != nil {
+		return nil, -1
+	}
+	return ebr.ToPartition(), -1
+}
+
+// GetLogicalPartitions recorre la lista enlazada de EBRs de la partición extendida
+func (mbr *MBR) GetLogicalPartitions(path string) ([]EBR, error) {
+	return mbr.readEBRChain(path, nil, -1)
+}
+
+// readEBRChain recorre la cadena de EBRs del disco; si override no es nil, se usa en lugar del EBR
+// guardado en offset para obtener la cadena como quedaría después de escribirlo
+func (mbr *MBR) readEBRChain(path string, override *EBR, offset int64) ([]EBR, error) {
+	// Obtener la partición extendida
+	extended, _ := mbr.GetExtendedPartition()
+	if extended == nil {
+		return nil, errors.New("no existe una partición extendida en el disco")
+	}
+
+	var ebrs []EBR
+	visited := make(map[int32]bool)
+
+	// El primer EBR siempre está al inicio de la partición extendida
+	next := extended.Part_start
+	for next != -1 {
+		// Evitar ciclos en la cadena de EBRs
+		if visited[next] {
+			return nil, fmt.Errorf("la cadena de EBRs tiene un ciclo en el byte %d", next)
+		}
+		visited[next] = true
+
+		// Deserializar el EBR, o usar el que se va a escribir en esa posición
+		ebr := EBR{}
+		if override != nil && int64(next) == offset {
+			ebr = *override
+		} else {
+			err := ebr.Deserialize(path, int64(next))
+			if err != nil {
+				return nil, fmt.Errorf("error al leer el EBR en el byte %d: %w", next, err)
+			}
+		}
+
+		ebrs = append(ebrs, ebr)
+		next = ebr.Part_next
+	}
+
+	return ebrs, nil
+}
+
+// GetLogicalPartitionByName busca el EBR de una partición lógica por nombre
+func (mbr *MBR) GetLogicalPartitionByName(name string, path string) (*EBR, error) {
+	// Obtener los EBRs de la partición extendida
+	ebrs, err := mbr.GetLogicalPartitions(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convertir el nombre de la partición a string y eliminar los caracteres nulos
+	inputName := strings.Trim(name, "\x00 ")
+	for i := range ebrs {
+		// El EBR inicial vacío no representa una partición lógica
+		if ebrs[i].Part_s <= 0 {
+			continue
+		}
+		// Convertir Part_name a string y eliminar los caracteres nulos
+		ebrName := strings.Trim(string(ebrs[i].Part_name[:]), "\x00 ")
+		if strings.EqualFold(ebrName, inputName) {
+			return &ebrs[i], nil
+		}
+	}
+	return nil, errors.New("partición lógica no encontrada")
+}
+
+// GetLogicalPartitionByID busca el EBR de una partición lógica montada por el id guardado en el EBR
+func (mbr *MBR) GetLogicalPartitionByID(id string, path string) (*EBR, error) {
+	// Obtener los EBRs de la partición extendida
+	ebrs, err := mbr.GetLogicalPartitions(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convertir el id a string y eliminar los caracteres nulos
+	inputID := strings.Trim(id, "\x00 ")
+	for i := range ebrs {
+		// Solo los EBRs montados tienen un id válido
+		if ebrs[i].Part_mount[0] != '1' {
+			continue
+		}
+		ebrID := strings.Trim(string(ebrs[i].Part_id[:]), "\x00 ")
+		if strings.EqualFold(ebrID, inputID) {
+			return &ebrs[i], nil
+		}
+	}
+	return nil, errors.New("partición lógica no encontrada")
+}
+
+// GetExtendedPartition obtiene la partición extendida del disco, si existe
+func (mbr *MBR) GetExtendedPartition() (*Partition, int) {
+	// Recorrer las particiones del MBR
+	for i := 0; i < len(mbr.Mbr_partitions); i++ {
+		// Solo puede existir una partición extendida por disco
+		if mbr.Mbr_partitions[i].Part_start != -1 && mbr.Mbr_partitions[i].Part_type[0] == 'E' {
+			return &mbr.Mbr_partitions[i], i
+		}
+	}
 	return nil, -1
 }
 
@@ -61,6 +61,29 @@ func (p *Partition) MountPartition(correlative int, id string) error {
 	return nil
 }
 
+// Desmontar una partición, liberando su correlativo e id
+func (p *Partition) UnmountPartition() {
+	// Asignar status de la partición
+	p.Part_status[0] = '0' // El valor '0' indica que la partición está creada pero no montada
+
+	// Liberar el correlativo y el ID de la partición
+	p.Part_correlative = -1
+	p.Part_id = [4]byte{'N'}
+}
+
+// Eliminar una partición, dejando la entrada del MBR disponible
+func (p *Partition) DeletePartition() {
+	// Restablecer los valores iniciales de una entrada sin partición
+	p.Part_status = [1]byte{'N'}
+	p.Part_type = [1]byte{'N'}
+	p.Part_fit = [1]byte{'N'}
+	p.Part_start = -1
+	p.Part_size = -1
+	p.Part_name = [16]byte{'N'}
+	p.Part_correlative = -1
+	p.Part_id = [4]byte{'N'}
+}
+
 // Imprimir los valores de la partición
 func (p *Partition) PrintPartition() {
 	fmt.Printf("Part_status: %c\n", p.Part_status[0])
@@ -0,0 +1,100 @@
+package structures
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// Marcador de las contraseñas cifradas en users.txt: pbkdf2-sha256$<iteraciones>$<sal>$<hash>.
+// Las iteraciones se guardan en cada entrada para poder aumentarlas sin invalidar las anteriores
+const passwordHashMarker = "pbkdf2-sha256$"
+
+// Marcador de las contraseñas cifradas con un único SHA-256: sha256$<sal>$<hash>.
+// Solo se verifican; las contraseñas nuevas siempre se cifran con PBKDF2
+const legacyHashMarker = "sha256$"
+
+// Cantidad de iteraciones de PBKDF2 para las contraseñas nuevas
+const passwordIterations = 100000
+
+// Cantidad de bytes aleatorios de la sal
+const passwordSaltSize = 16
+
+// Cantidad de bytes del hash derivado
+const passwordKeySize = 32
+
+// HashPassword cifra una contraseña con PBKDF2-SHA256 y una sal aleatoria
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, passwordSaltSize)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, passwordIterations, passwordKeySize)
+	if err != nil {
+		return "", err
+	}
+
+	return passwordHashMarker + strconv.Itoa(passwordIterations) + "$" + hex.EncodeToString(salt) + "$" + hex.EncodeToString(key), nil
+}
+
+// VerifyPassword compara una contraseña con la guardada en users.txt. Solo las entradas sin
+// marcador se comparan en texto plano: son anteriores al cifrado
+func VerifyPassword(stored string, password string) bool {
+	switch {
+	case strings.HasPrefix(stored, passwordHashMarker):
+		return verifyPBKDF2(strings.TrimPrefix(stored, passwordHashMarker), password)
+	case strings.HasPrefix(stored, legacyHashMarker):
+		return verifyLegacySHA256(strings.TrimPrefix(stored, legacyHashMarker), password)
+	default:
+		return subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1
+	}
+}
+
+// IsPasswordHashed indica si la contraseña guardada tiene alguno de los marcadores de cifrado
+func IsPasswordHashed(stored string) bool {
+	return strings.HasPrefix(stored, passwordHashMarker) || strings.HasPrefix(stored, legacyHashMarker)
+}
+
+// verifyPBKDF2 verifica una entrada <iteraciones>$<sal>$<hash> derivando la clave con las mismas iteraciones
+func verifyPBKDF2(entry string, password string) bool {
+	parts := strings.Split(entry, "$")
+	if len(parts) != 3 {
+		return false
+	}
+
+	iterations, err := strconv.Atoi(parts[0])
+	if err != nil || iterations < 1 {
+		return false
+	}
+	salt, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	hash, err := hex.DecodeString(parts[2])
+	if err != nil || len(hash) == 0 {
+		return false
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, iterations, len(hash))
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(hash, key) == 1
+}
+
+// verifyLegacySHA256 verifica una entrada <sal>$<hash> calculada con SHA-256 de la sal seguida de la contraseña
+func verifyLegacySHA256(entry string, password string) bool {
+	salt, hash, found := strings.Cut(entry, "$")
+	if !found {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(salt + password))
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(hex.EncodeToString(sum[:]))) == 1
+}
\ No newline at end of file
@@ -0,0 +1,153 @@
+package structures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errores de la resolución de rutas, se pueden comparar con errors.Is
+var (
+	ErrNotFound     = errors.New("no existe el archivo o carpeta")
+	ErrNotDirectory = errors.New("no es una carpeta")
+	ErrInvalidPath  = errors.New("ruta inválida")
+
+	ErrParentNotFound = errors.New("la carpeta padre no existe")
+	ErrAlreadyExists  = errors.New("ya existe un archivo o carpeta con ese nombre")
+	ErrNameTooLong    = errors.New("el nombre excede el límite de 12 bytes")
+	ErrInvalidName    = errors.New("nombre inválido")
+)
+
+// Longitud máxima en bytes de un nombre dentro de una carpeta (B_name)
+const MaxNameLength = 12
+
+// ValidateName verifica que un nombre se pueda guardar en una entrada de carpeta.
+// Se permiten espacios, pero no nombres vacíos, . ni .., ni más de MaxNameLength bytes
+func ValidateName(name string) error {
+	if strings.TrimSpace(name) == "" || name == "." || name == ".." || strings.ContainsRune(name, 0) {
+		return fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	if len(name) > MaxNameLength {
+		return fmt.Errorf("%w: %s (%d bytes)", ErrNameTooLong, name, len(name))
+	}
+	return nil
+}
+
+// SplitPath separa una ruta absoluta en sus componentes, ignorando las barras repetidas o finales.
+// Los componentes . y .. se resuelven sobre la propia ruta (.. en la raíz se queda en la raíz),
+// así las carpetas que faltan se calculan sobre la ruta ya normalizada
+func SplitPath(filePath string) ([]string, error) {
+	if !strings.HasPrefix(filePath, "/") {
+		return nil, fmt.Errorf("%w: la ruta debe ser absoluta: %s", ErrInvalidPath, filePath)
+	}
+
+	var components []string
+	for _, component := range strings.Split(filePath, "/") {
+		switch component {
+		case "", ".":
+			continue
+		case "..":
+			if len(components) > 0 {
+				components = components[:len(components)-1]
+			}
+		default:
+			components = append(components, component)
+		}
+	}
+	return components, nil
+}
+
+// ResolvePath obtiene el índice y el inodo de una ruta absoluta del sistema de archivos.
+// Se aceptan los componentes . y .. (ver SplitPath), y las barras finales
+func (sb *SuperBlock) ResolvePath(path string, filePath string) (int32, *Inode, error) {
+	components, err := SplitPath(filePath)
+	if err != nil {
+		return -1, nil, err
+	}
+
+	inodeIndex, err := sb.resolveComponents(path, components)
+	if err != nil {
+		return -1, nil, err
+	}
+
+	inode, err := sb.readInode(path, inodeIndex)
+	if err != nil {
+		return -1, nil, err
+	}
+
+	return inodeIndex, inode, nil
+}
+
+// LookupChild busca una entrada por nombre dentro de la carpeta dirIndex y devuelve el índice de su inodo
+func (sb *SuperBlock) LookupChild(path string, dirIndex int32, name string) (int32, error) {
+	dir, err := sb.readInode(path, dirIndex)
+	if err != nil {
+		return -1, err
+	}
+	if dir.I_type[0] != '0' {
+		return -1, ErrNotDirectory
+	}
+
+	switch name {
+	case ".":
+		return dirIndex, nil
+	case "..":
+		return sb.parentOf(path, dir, dirIndex)
+	}
+
+	childIndex, err := sb.findInFolder(path, dirIndex, name)
+	if err != nil {
+		return -1, err
+	}
+	if childIndex == -1 {
+		return -1, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	return childIndex, nil
+}
+
+// resolveComponents recorre los componentes de una ruta desde la raíz
+func (sb *SuperBlock) resolveComponents(path string, components []string) (int32, error) {
+	inodeIndex := int32(0)
+	for i, component := range components {
+		childIndex, err := sb.LookupChild(path, inodeIndex, component)
+		if errors.Is(err, ErrNotDirectory) {
+			return -1, fmt.Errorf("%w: /%s", ErrNotDirectory, strings.Join(components[:i], "/"))
+		}
+		if err != nil {
+			return -1, err
+		}
+		inodeIndex = childIndex
+	}
+
+	return inodeIndex, nil
+}
+
+// parentOf obtiene la carpeta padre a partir de la entrada .. del primer bloque de la carpeta
+func (sb *SuperBlock) parentOf(path string, dir *Inode, dirIndex int32) (int32, error) {
+	if dir.I_block[0] == -1 {
+		return dirIndex, nil
+	}
+
+	block := &FolderBlock{}
+	err := block.Deserialize(path, int64(sb.S_block_start+(dir.I_block[0]*sb.S_block_size)))
+	if err != nil {
+		return -1, err
+	}
+
+	return block.B_content[1].B_inodo, nil
+}
+
+// readInode lee el inodo con el índice indicado
+func (sb *SuperBlock) readInode(path string, inodeIndex int32) (*Inode, error) {
+	if inodeIndex < 0 || inodeIndex >= sb.TotalInodes() {
+		return nil, fmt.Errorf("inodo fuera de rango: %d", inodeIndex)
+	}
+
+	inode := &Inode{}
+	err := inode.Deserialize(path, int64(sb.S_inode_start+(inodeIndex*sb.S_inode_size)))
+	if err != nil {
+		return nil, err
+	}
+	return inode, nil
+}
@@ -0,0 +1,111 @@
+package structures
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestFileSystem crea una imagen temporal con un sistema EXT2 de n inodos y 3n bloques
+func newTestFileSystem(t *testing.T, n int32) (*SuperBlock, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "disco.mia")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	inodeSize := int32(binary.Size(Inode{}))
+	bmInodeStart := int32(binary.Size(SuperBlock{}))
+	sb := &SuperBlock{
+		S_filesystem_type:   2,
+		S_free_inodes_count: n,
+		S_free_blocks_count: 3 * n,
+		S_magic:             0xEF53,
+		S_inode_size:        inodeSize,
+		S_block_size:        int32(binary.Size(FileBlock{})),
+		S_bm_inode_start:    bmInodeStart,
+		S_bm_block_start:    bmInodeStart + n,
+		S_inode_start:       bmInodeStart + 4*n,
+		S_block_start:       bmInodeStart + 4*n + inodeSize*n,
+	}
+	sb.S_first_ino = sb.S_inode_start
+	sb.S_first_blo = sb.S_block_start
+
+	if err := sb.CreateBitMaps(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := sb.CreateUsersFileExt2(path); err != nil {
+		t.Fatal(err)
+	}
+	return sb, path
+}
+
+func TestResolvePathDistinguishesCase(t *testing.T) {
+	sb, path := newTestFileSystem(t, 16)
+
+	if err := sb.CreateFolder(path, "/X", false, 1, 1); err != nil {
+		t.Fatalf("mkdir /X: %v", err)
+	}
+	if err := sb.CreateFile(path, "/x", "minúscula", false, 1, 1); err != nil {
+		t.Fatalf("mkfile /x: %v", err)
+	}
+
+	upperIndex, upper, err := sb.ResolvePath(path, "/X")
+	if err != nil {
+		t.Fatalf("ResolvePath /X: %v", err)
+	}
+	lowerIndex, lower, err := sb.ResolvePath(path, "/x")
+	if err != nil {
+		t.Fatalf("ResolvePath /x: %v", err)
+	}
+	if upperIndex == lowerIndex {
+		t.Fatalf("/X y /x resolvieron al mismo inodo %d", upperIndex)
+	}
+	if upper.I_type[0] != '0' || lower.I_type[0] != '1' {
+		t.Fatalf("tipos inesperados: /X=%c /x=%c", upper.I_type[0], lower.I_type[0])
+	}
+
+	childIndex, err := sb.LookupChild(path, 0, "x")
+	if err != nil || childIndex != lowerIndex {
+		t.Fatalf("LookupChild x = %d, %v; se esperaba %d", childIndex, err, lowerIndex)
+	}
+	childIndex, err = sb.LookupChild(path, 0, "X")
+	if err != nil || childIndex != upperIndex {
+		t.Fatalf("LookupChild X = %d, %v; se esperaba %d", childIndex, err, upperIndex)
+	}
+
+	if _, err := sb.LookupChild(path, 0, "USERS.TXT"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("LookupChild USERS.TXT = %v; se esperaba ErrNotFound", err)
+	}
+}
+
+func TestCreateFolderNormalizesDotComponents(t *testing.T) {
+	sb, path := newTestFileSystem(t, 16)
+
+	// Los componentes . y .. se resuelven antes de buscar las carpetas que faltan
+	if err := sb.CreateFolder(path, "/d/f151/../f152", true, 1, 1); err != nil {
+		t.Fatalf("mkdir -p /d/f151/../f152: %v", err)
+	}
+	if _, _, err := sb.ResolvePath(path, "/d/f152"); err != nil {
+		t.Fatalf("ResolvePath /d/f152: %v", err)
+	}
+	if _, _, err := sb.ResolvePath(path, "/d/f151"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("ResolvePath /d/f151 = %v; se esperaba ErrNotFound", err)
+	}
+
+	if err := sb.CreateFile(path, "/d/./f152/../../a.txt", "hola", false, 1, 1); err != nil {
+		t.Fatalf("mkfile /d/./f152/../../a.txt: %v", err)
+	}
+	fileIndex, _, err := sb.ResolvePath(path, "/../a.txt")
+	if err != nil {
+		t.Fatalf("ResolvePath /../a.txt: %v", err)
+	}
+	if sameIndex, _, _ := sb.ResolvePath(path, "/d/f152/../../a.txt"); sameIndex != fileIndex {
+		t.Fatalf("/d/f152/../../a.txt = %d; se esperaba %d", sameIndex, fileIndex)
+	}
+}
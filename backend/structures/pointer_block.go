@@ -10,6 +10,30 @@ type PointerBlock struct {
 	// Total: 64 bytes
 }
 
+// Serialize escribe la estructura PointerBlock en un archivo binario en la posición especificada
+func (pb *PointerBlock) Serialize(path string, offset int64) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Mover el puntero del archivo a la posición especificada
+	_, err = file.Seek(offset, 0)
+	if err != nil {
+		return err
+	}
+
+	// Serializar la estructura PointerBlock directamente en el archivo
+	err = binary.Write(file, binary.LittleEndian, pb)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Deserialize lee la estructura PointerBlock desde un archivo binario en la posición especificada
 func (pb *PointerBlock) Deserialize(path string, offset int64) error {
 	file, err := os.Open(path)
 	if err != nil {
@@ -3,6 +3,7 @@ package structures
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -119,8 +120,13 @@ func (sb *SuperBlock) Print() {
 func (sb *SuperBlock) PrintInodes(path string) error {
 	// Imprimir inodos
 	fmt.Println("\nInodos\n----------------")
+	// Obtener los inodos ocupados según el bitmap
+	usedInodes, err := sb.UsedInodes(path)
+	if err != nil {
+		return err
+	}
 	// Iterar sobre cada inodo
-	for i := int32(0); i < sb.S_inodes_count; i++ {
+	for _, i := range usedInodes {
 		inode := &Inode{}
 		// Deserializar el inodo
 		err := inode.Deserialize(path, int64(sb.S_inode_start+(i*sb.S_inode_size)))
@@ -139,8 +145,13 @@ func (sb *SuperBlock) PrintInodes(path string) error {
 func (sb *SuperBlock) PrintBlocks(path string) error {
 	// Imprimir bloques
 	fmt.Println("\nBloques\n----------------")
+	// Obtener los inodos ocupados según el bitmap
+	usedInodes, err := sb.UsedInodes(path)
+	if err != nil {
+		return err
+	}
 	// Iterar sobre cada inodo
-	for i := int32(0); i < sb.S_inodes_count; i++ {
+	for _, i := range usedInodes {
 		inode := &Inode{}
 		// Deserializar el inodo
 		err := inode.Deserialize(path, int64(sb.S_inode_start+(i*sb.S_inode_size)))
@@ -148,10 +159,20 @@ func (sb *SuperBlock) PrintBlocks(path string) error {
 			return err
 		}
 		// Iterar sobre cada bloque del inodo (apuntadores)
-		for _, blockIndex := range inode.I_block {
-			// Si el bloque no existe, salir
+		for j, blockIndex := range inode.I_block {
+			// Si el bloque no existe, continuar con el siguiente
 			if blockIndex == -1 {
-				break
+				continue
+			}
+			// Los apuntadores 12, 13 y 14 son bloques de apuntadores
+			if j >= 12 {
+				block := &PointerBlock{}
+				err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size)))
+				if err != nil {
+					return err
+				}
+				fmt.Printf("\nBloque %d (apuntadores):\n%v\n", blockIndex, block.P_pointers)
+				continue
 			}
 			// Si el inodo es de tipo carpeta
 			if inode.I_type[0] == '0' {
@@ -186,54 +207,35 @@ func (sb *SuperBlock) PrintBlocks(path string) error {
 	return nil
 }
 
-// Get users.txt block
-func (sb *SuperBlock) GetUsersBlock(path string) (*FileBlock, error) {
-	// Ir al inodo 1
-	inode := &Inode{}
-
-	// Deserializar el inodo
-	err := inode.Deserialize(path, int64(sb.S_inode_start+(1*sb.S_inode_size))) // 1 porque es el inodo 1
+// CreateFolder crea una carpeta en el sistema de archivos.
+// Si createParents es verdadero, se crean también las carpetas padre que no existan
+func (sb *SuperBlock) CreateFolder(path string, folderPath string, createParents bool, uid int32, gid int32) error {
+	components, err := SplitPath(folderPath)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// Iterar sobre cada bloque del inodo (apuntadores)
-	for _, blockIndex := range inode.I_block {
-		// Si el bloque no existe, salir
-		if blockIndex == -1 {
-			break
-		}
-		// Si el inodo es de tipo archivo
-		if inode.I_type[0] == '1' {
-			block := &FileBlock{}
-			// Deserializar el bloque
-			err := block.Deserialize(path, int64(sb.S_block_start+(blockIndex*sb.S_block_size))) // 64 porque es el tamaño de un bloque
-			if err != nil {
-				return nil, err
-			}
-			// Deben ir guardando todo el contenido de los bloques en una variable
-
-			// Retornar el bloque por temas explicativos
-			return block, nil
-		}
+	// La raíz ya existe
+	if len(components) == 0 {
+		return fmt.Errorf("%w: %s", ErrAlreadyExists, folderPath)
 	}
-	return nil, fmt.Errorf("users.txt block not found")
-}
 
-// CreateFolder crea una carpeta en el sistema de archivos
-func (sb *SuperBlock) CreateFolder(path string, parentsDir []string, destDir string) error {
-	// Si parentsDir está vacío, solo trabajar con el primer inodo que sería el raíz "/"
-	if len(parentsDir) == 0 {
-		return sb.createFolderInInodeExt2(path, 0, parentsDir, destDir)
+	// Validar el nombre antes de crear las carpetas padre
+	err = ValidateName(components[len(components)-1])
+	if err != nil {
+		return err
 	}
 
-	// Iterar sobre cada inodo ya que se necesita buscar el inodo padre
-	for i := int32(0); i < sb.S_inodes_count; i++ {
-		err := sb.createFolderInInodeExt2(path, i, parentsDir, destDir)
-		if err != nil {
-			return err
-		}
+	// Verificar el espacio y buscar (o crear) la carpeta padre
+	parentIndex, created, err := sb.prepareEntry(path, components, 1, createParents, uid, gid)
+	if err != nil {
+		return err
 	}
 
+	// Crear la carpeta dentro de la carpeta padre; si falla se eliminan también las carpetas padre creadas
+	_, err = sb.createFolderIn(path, parentIndex, components[len(components)-1], uid, gid)
+	if err != nil {
+		return errors.Join(err, sb.discardFolders(path, created))
+	}
 	return nil
 }
\ No newline at end of file
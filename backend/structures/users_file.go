@@ -0,0 +1,191 @@
+package structures
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Ruta del archivo de usuarios y grupos en la raíz de cada partición
+const UsersFilePath = "/users.txt"
+
+/*
+   Formato de users.txt:
+   1,G,root            -> ID, tipo G, nombre del grupo
+   1,U,root,root,sha256$<sal>$<hash>   -> ID, tipo U, grupo, usuario, contraseña cifrada
+   Un ID 0 indica que el grupo o usuario fue eliminado.
+   Las contraseñas sin el marcador sha256$ son entradas antiguas en texto plano
+*/
+
+// GroupRecord línea de tipo G de users.txt
+type GroupRecord struct {
+	ID   int32
+	Name string
+}
+
+// UserRecord línea de tipo U de users.txt
+type UserRecord struct {
+	ID       int32
+	Group    string
+	Name     string
+	Password string // Contraseña cifrada (ver HashPassword) o en texto plano en entradas antiguas
+}
+
+// UsersFile contenido de users.txt separado en grupos y usuarios
+type UsersFile struct {
+	Groups []*GroupRecord
+	Users  []*UserRecord
+	lines  []fmt.Stringer // Registros en el orden del archivo, para escribirlo sin reordenar
+}
+
+// String devuelve la línea de users.txt del grupo
+func (g *GroupRecord) String() string {
+	return fmt.Sprintf("%d,G,%s", g.ID, g.Name)
+}
+
+// String devuelve la línea de users.txt del usuario
+func (u *UserRecord) String() string {
+	return fmt.Sprintf("%d,U,%s,%s,%s", u.ID, u.Group, u.Name, u.Password)
+}
+
+// ParseUsersFile separa el contenido de users.txt en registros de grupos y usuarios
+func ParseUsersFile(content string) (*UsersFile, error) {
+	users := &UsersFile{}
+
+	for number, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// Dividir la línea en campos y limpiar los espacios en blanco
+		fields := strings.Split(line, ",")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		id, err := strconv.ParseInt(fields[0], 10, 32)
+		if err != nil || id < 0 || len(fields) < 2 {
+			return nil, fmt.Errorf("línea %d inválida en %s: %s", number+1, UsersFilePath, line)
+		}
+
+		switch {
+		case fields[1] == "G" && len(fields) == 3:
+			group := &GroupRecord{ID: int32(id), Name: fields[2]}
+			users.Groups = append(users.Groups, group)
+			users.lines = append(users.lines, group)
+		case fields[1] == "U" && len(fields) == 5:
+			user := &UserRecord{ID: int32(id), Group: fields[2], Name: fields[3], Password: fields[4]}
+			users.Users = append(users.Users, user)
+			users.lines = append(users.lines, user)
+		default:
+			return nil, fmt.Errorf("línea %d inválida en %s: %s", number+1, UsersFilePath, line)
+		}
+	}
+
+	return users, nil
+}
+
+// String devuelve el contenido de users.txt con una línea por registro
+func (u *UsersFile) String() string {
+	var content strings.Builder
+	for _, line := range u.lines {
+		content.WriteString(line.String() + "\n")
+	}
+	return content.String()
+}
+
+// FindGroup busca un grupo activo (ID distinto de 0) por nombre
+func (u *UsersFile) FindGroup(name string) *GroupRecord {
+	for _, group := range u.Groups {
+		if group.ID != 0 && group.Name == name {
+			return group
+		}
+	}
+	return nil
+}
+
+// FindUser busca un usuario activo (ID distinto de 0) por nombre
+func (u *UsersFile) FindUser(name string) *UserRecord {
+	for _, user := range u.Users {
+		if user.ID != 0 && user.Name == name {
+			return user
+		}
+	}
+	return nil
+}
+
+// UsersInGroup obtiene los usuarios activos que pertenecen al grupo indicado
+func (u *UsersFile) UsersInGroup(group string) []*UserRecord {
+	var members []*UserRecord
+	for _, user := range u.Users {
+		if user.ID != 0 && user.Group == group {
+			members = append(members, user)
+		}
+	}
+	return members
+}
+
+// AddGroup agrega un grupo al final del archivo con el siguiente ID.
+// Los grupos eliminados también cuentan para no reutilizar sus IDs
+func (u *UsersFile) AddGroup(name string) *GroupRecord {
+	group := &GroupRecord{ID: int32(len(u.Groups) + 1), Name: name}
+	u.Groups = append(u.Groups, group)
+	u.lines = append(u.lines, group)
+	return group
+}
+
+// AddUser agrega un usuario al final del archivo con el siguiente ID y la contraseña cifrada.
+// Los usuarios eliminados también cuentan para no reutilizar sus IDs
+func (u *UsersFile) AddUser(group string, name string, password string) (*UserRecord, error) {
+	user := &UserRecord{ID: int32(len(u.Users) + 1), Group: group, Name: name}
+	err := user.SetPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Users = append(u.Users, user)
+	u.lines = append(u.lines, user)
+	return user, nil
+}
+
+// SetPassword asigna la contraseña del usuario guardándola cifrada. Solo la usa mkusr al crear
+// el usuario: las entradas antiguas en texto plano se conservan tal cual y se verifican en texto plano
+func (u *UserRecord) SetPassword(password string) error {
+	hash, err := HashPassword(password)
+	if err != nil {
+		return err
+	}
+	u.Password = hash
+	return nil
+}
+
+// CheckPassword verifica la contraseña del usuario, cifrada o en texto plano
+func (u *UserRecord) CheckPassword(password string) bool {
+	return VerifyPassword(u.Password, password)
+}
+
+// ReadUsersFile lee users.txt completo, recorriendo todos sus bloques, y lo separa en registros
+func (sb *SuperBlock) ReadUsersFile(path string) (*UsersFile, error) {
+	_, inode, err := sb.ResolvePath(path, UsersFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := sb.ReadFileContent(path, inode)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseUsersFile(content)
+}
+
+// WriteUsersFile escribe los registros en users.txt, reservando o liberando bloques según el nuevo tamaño.
+// El llamador es responsable de serializar el superbloque
+func (sb *SuperBlock) WriteUsersFile(path string, users *UsersFile) error {
+	inodeIndex, inode, err := sb.ResolvePath(path, UsersFilePath)
+	if err != nil {
+		return err
+	}
+
+	return sb.WriteFileContent(path, inodeIndex, inode, users.String())
+}
\ No newline at end of file
@@ -0,0 +1,68 @@
+package structures
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestSetPasswordHashesPlainEntry(t *testing.T) {
+	users, err := ParseUsersFile("1,G,root\n1,U,root,root,123\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := users.FindUser("root")
+
+	// Las entradas sin marcador se comparan en texto plano
+	if IsPasswordHashed(root.Password) || !root.CheckPassword("123") || root.CheckPassword("1234") {
+		t.Fatalf("verificación en texto plano incorrecta para %q", root.Password)
+	}
+
+	if err := root.SetPassword("123"); err != nil {
+		t.Fatal(err)
+	}
+	if !IsPasswordHashed(root.Password) {
+		t.Fatalf("la contraseña no quedó cifrada: %q", root.Password)
+	}
+	if !root.CheckPassword("123") || root.CheckPassword("1234") {
+		t.Fatal("la contraseña cifrada no se verifica correctamente")
+	}
+
+	// El hash guardado no sirve como contraseña
+	if root.CheckPassword(root.Password) {
+		t.Fatal("el hash guardado no debería aceptarse como contraseña")
+	}
+
+	// El archivo reescrito conserva el orden y ya no contiene la contraseña en texto plano
+	reparsed, err := ParseUsersFile(users.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(users.String(), ",123\n") || !reparsed.FindUser("root").CheckPassword("123") {
+		t.Fatalf("users.txt reescrito incorrecto:\n%s", users.String())
+	}
+}
+
+func TestHashPasswordRecordsIterations(t *testing.T) {
+	stored, err := HashPassword("123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(stored, "pbkdf2-sha256$100000$") || !VerifyPassword(stored, "123") || VerifyPassword(stored, "1234") {
+		t.Fatalf("hash PBKDF2 incorrecto: %q", stored)
+	}
+
+	// Las iteraciones se leen de la entrada, no de la constante actual
+	fewer := strings.Replace(stored, "$100000$", "$1000$", 1)
+	if VerifyPassword(fewer, "123") {
+		t.Fatal("una entrada con otras iteraciones no debería coincidir con el mismo hash")
+	}
+
+	// Las entradas cifradas con un único SHA-256 se siguen verificando y no se comparan en texto plano
+	sum := sha256.Sum256([]byte("abcd" + "123"))
+	legacy := "sha256$abcd$" + hex.EncodeToString(sum[:])
+	if !IsPasswordHashed(legacy) || !VerifyPassword(legacy, "123") || VerifyPassword(legacy, legacy) {
+		t.Fatalf("entrada SHA-256 antigua mal verificada: %q", legacy)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,202 @@
+package structures
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// occupiedRange representa el rango de bytes que ocupa una partición dentro del disco
+type occupiedRange struct {
+	name  string
+	start int32
+	end   int32
+}
+
+// ValidatePartitionTable revisa las invariantes del MBR y de la cadena de EBRs y devuelve los problemas encontrados
+func ValidatePartitionTable(mbr *MBR, ebrs []EBR) []string {
+	var problems []string
+	var ranges []occupiedRange
+	names := make(map[string]bool)
+
+	// Validar cada partición del MBR
+	mbrSize := int32(binary.Size(mbr))
+	extendedCount := 0
+	var extended *Partition
+	for i := range mbr.Mbr_partitions {
+		partition := &mbr.Mbr_partitions[i]
+		// Las entradas libres no se validan
+		if partition.Part_start == -1 {
+			continue
+		}
+
+		name := strings.Trim(string(partition.Part_name[:]), "\x00 ")
+
+		// Verificar el tamaño y los límites de la partición
+		if partition.Part_size <= 0 {
+			problems = append(problems, fmt.Sprintf("la partición %s tiene un tamaño inválido (%d)", name, partition.Part_size))
+		}
+		if partition.Part_start < mbrSize {
+			problems = append(problems, fmt.Sprintf("la partición %s inicia dentro del MBR (byte %d)", name, partition.Part_start))
+		}
+		if partition.Part_start+partition.Part_size > mbr.Mbr_size {
+			problems = append(problems, fmt.Sprintf("la partición %s sobrepasa el final del disco", name))
+		}
+
+		// Verificar que el nombre no esté repetido
+		if names[strings.ToLower(name)] {
+			problems = append(problems, fmt.Sprintf("el nombre de partición %s está repetido", name))
+		}
+		names[strings.ToLower(name)] = true
+
+		// Contar las particiones extendidas
+		if partition.Part_type[0] == 'E' {
+			extendedCount++
+			if extended == nil {
+				extended = partition
+			}
+		}
+
+		ranges = append(ranges, occupiedRange{name: name, start: partition.Part_start, end: partition.Part_start + partition.Part_size})
+	}
+
+	// Solo puede existir una partición extendida
+	if extendedCount > 1 {
+		problems = append(problems, fmt.Sprintf("el disco tiene %d particiones extendidas, solo se permite una", extendedCount))
+	}
+
+	// Verificar que las particiones no se traslapen
+	for i := 0; i < len(ranges); i++ {
+		for j := i + 1; j < len(ranges); j++ {
+			if ranges[i].start < ranges[j].end && ranges[j].start < ranges[i].end {
+				problems = append(problems, fmt.Sprintf("las particiones %s y %s se traslapan", ranges[i].name, ranges[j].name))
+			}
+		}
+	}
+
+	// Validar la cadena de EBRs de la partición extendida
+	if extended != nil {
+		problems = append(problems, validateEBRChain(extended, ebrs, names)...)
+	}
+
+	return problems
+}
+
+// validateEBRChain revisa que los EBRs estén dentro de la extendida, ordenados y sin traslaparse
+func validateEBRChain(extended *Partition, ebrs []EBR, names map[string]bool) []string {
+	var problems []string
+
+	// Si la cadena no se pudo leer ya se reportó el problema
+	if len(ebrs) == 0 {
+		return problems
+	}
+
+	// El primer EBR debe estar al inicio de la partición extendida
+	if ebrs[0].Part_start != extended.Part_start {
+		return append(problems, "la partición extendida no tiene un EBR inicial en su primer byte")
+	}
+
+	ebrSize := int32(binary.Size(EBR{}))
+	extendedEnd := extended.Part_start + extended.Part_size
+	for i, ebr := range ebrs {
+		name := strings.Trim(string(ebr.Part_name[:]), "\x00 ")
+		end := ebr.Part_start + ebrSize
+		if ebr.Part_s > 0 {
+			end += ebr.Part_s
+		}
+
+		// La partición lógica debe quedar dentro de la extendida
+		if ebr.Part_start < extended.Part_start || end > extendedEnd {
+			problems = append(problems, fmt.Sprintf("el EBR en el byte %d sobrepasa la partición extendida", ebr.Part_start))
+		}
+
+		// El siguiente EBR debe estar después de la partición lógica actual
+		if ebr.Part_next != -1 && (ebr.Part_next < end || ebr.Part_next >= extendedEnd) {
+			problems = append(problems, fmt.Sprintf("el EBR en el byte %d apunta a un siguiente EBR inválido (%d)", ebr.Part_start, ebr.Part_next))
+		}
+		if i+1 < len(ebrs) && ebrs[i+1].Part_start != ebr.Part_next {
+			problems = append(problems, fmt.Sprintf("el EBR en el byte %d no coincide con el enlace del EBR anterior", ebrs[i+1].Part_start))
+		}
+
+		// Solo los EBRs con datos representan particiones lógicas con nombre
+		if ebr.Part_s <= 0 {
+			continue
+		}
+		if names[strings.ToLower(name)] {
+			problems = append(problems, fmt.Sprintf("el nombre de partición %s está repetido", name))
+		}
+		names[strings.ToLower(name)] = true
+	}
+
+	return problems
+}
+
+// Validate lee la cadena de EBRs del disco y valida la tabla de particiones completa
+func (mbr *MBR) Validate(path string) []string {
+	return mbr.validateChain(path, nil, -1)
+}
+
+// validateChain valida la tabla de particiones leyendo la cadena de EBRs del disco.
+// Si override no es nil, se valida como si ya estuviera escrito en offset
+func (mbr *MBR) validateChain(path string, override *EBR, offset int64) []string {
+	var problems []string
+	var ebrs []EBR
+
+	// Leer la cadena de EBRs si existe una partición extendida
+	if extended, _ := mbr.GetExtendedPartition(); extended != nil {
+		var err error
+		ebrs, err = mbr.readEBRChain(path, override, offset)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("la cadena de EBRs está rota: %v", err))
+		}
+	} else if override != nil {
+		problems = append(problems, fmt.Sprintf("el EBR en el byte %d no pertenece a ninguna partición extendida", offset))
+	}
+
+	return append(problems, ValidatePartitionTable(mbr, ebrs)...)
+}
+
+// validateEBRWrite valida la tabla de particiones del disco como quedaría después de escribir el EBR en offset
+func validateEBRWrite(path string, ebr *EBR, offset int64) error {
+	var mbr MBR
+	err := mbr.Deserialize(path)
+	if err != nil {
+		return err
+	}
+
+	return checkTableWrite(path, mbr.validateChain(path, ebr, offset))
+}
+
+// checkTableWrite decide si se puede escribir una tabla con los problemas indicados. Solo se rechazan
+// los problemas nuevos: si la tabla del disco ya era inválida, se permiten las escrituras que no la empeoran,
+// como eliminar la partición que causa el problema
+func checkTableWrite(path string, problems []string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+
+	// Problemas que ya tiene la tabla guardada en el disco
+	existing := make(map[string]bool)
+	var current MBR
+	if err := current.Deserialize(path); err == nil {
+		for _, problem := range current.Validate(path) {
+			existing[problem] = true
+		}
+	}
+
+	var added []string
+	for _, problem := range problems {
+		if !existing[problem] {
+			added = append(added, problem)
+		}
+	}
+	return PartitionTableError(added)
+}
+
+// PartitionTableError convierte los problemas encontrados en un error, o nil si no hay problemas
+func PartitionTableError(problems []string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("tabla de particiones inválida: %s", strings.Join(problems, "; "))
+}
@@ -0,0 +1,95 @@
+package structures
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestMBR crea un disco de 64 KB con un MBR sin particiones, escrito sin validar
+func newTestMBR(t *testing.T) (*MBR, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "disco.mia")
+	mbr := &MBR{Mbr_size: 64 * 1024, Mbr_disk_signature: 1, Mbr_disk_fit: [1]byte{'F'}}
+	for i := range mbr.Mbr_partitions {
+		mbr.Mbr_partitions[i] = Partition{Part_status: [1]byte{'N'}, Part_start: -1, Part_size: -1, Part_correlative: -1}
+	}
+	if err := os.WriteFile(path, make([]byte, mbr.Mbr_size), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := mbr.write(path); err != nil {
+		t.Fatal(err)
+	}
+	return mbr, path
+}
+
+func TestMBRSerializeAllowsFixingAnInvalidTable(t *testing.T) {
+	mbr, path := newTestMBR(t)
+
+	// Dejar en el disco dos particiones traslapadas
+	mbr.Mbr_partitions[0].CreatePartition(1024, 8*1024, "P", "F", "A")
+	mbr.Mbr_partitions[1].CreatePartition(4*1024, 8*1024, "P", "F", "B")
+	if err := mbr.write(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// Agregar otra partición traslapada empeora la tabla
+	mbr.Mbr_partitions[2].CreatePartition(2*1024, 4*1024, "P", "F", "C")
+	if err := mbr.Serialize(path); err == nil || !strings.Contains(err.Error(), "C") {
+		t.Fatalf("Serialize con un traslape nuevo = %v; se esperaba un error", err)
+	}
+	mbr.Mbr_partitions[2].DeletePartition()
+
+	// Cambios que no agregan problemas se permiten aunque la tabla siga inválida
+	mbr.Mbr_partitions[2].CreatePartition(32*1024, 4*1024, "P", "F", "C")
+	if err := mbr.Serialize(path); err != nil {
+		t.Fatalf("Serialize sin problemas nuevos: %v", err)
+	}
+
+	// Eliminar la partición problemática deja la tabla válida
+	mbr.Mbr_partitions[1].DeletePartition()
+	if err := mbr.Serialize(path); err != nil {
+		t.Fatalf("Serialize al eliminar B: %v", err)
+	}
+	if problems := mbr.Validate(path); len(problems) != 0 {
+		t.Fatalf("la tabla sigue inválida: %v", problems)
+	}
+}
+
+func TestEBRSerializeValidatesChain(t *testing.T) {
+	mbr, path := newTestMBR(t)
+	ebrSize := int32(binary.Size(EBR{}))
+
+	// Sin partición extendida no se puede escribir un EBR
+	ebr := &EBR{}
+	ebr.CreateEBR(16*1024, 0, "F", "")
+	if err := ebr.Serialize(path, int64(ebr.Part_start)); err == nil {
+		t.Fatal("se esperaba un error al escribir un EBR sin partición extendida")
+	}
+
+	mbr.Mbr_partitions[0].CreatePartition(16*1024, 8*1024, "E", "F", "Ext")
+	if err := mbr.SerializeWithChain(path, []EBR{*ebr}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ebr.Serialize(path, int64(ebr.Part_start)); err != nil {
+		t.Fatalf("EBR inicial: %v", err)
+	}
+
+	// Una partición lógica más grande que la extendida se rechaza y no se escribe
+	ebr.CreateEBR(16*1024, 8*1024, "F", "Log1")
+	if err := ebr.Serialize(path, int64(ebr.Part_start)); err == nil {
+		t.Fatal("se esperaba un error al escribir una lógica que sobrepasa la extendida")
+	}
+	ebrs, err := mbr.GetLogicalPartitions(path)
+	if err != nil || ebrs[0].Part_s != 0 {
+		t.Fatalf("el EBR rechazado se escribió: %+v, %v", ebrs, err)
+	}
+
+	ebr.CreateEBR(16*1024, 8*1024-int(ebrSize), "F", "Log1")
+	if err := ebr.Serialize(path, int64(ebr.Part_start)); err != nil {
+		t.Fatalf("lógica que ocupa toda la extendida: %v", err)
+	}
+}
\ No newline at end of file
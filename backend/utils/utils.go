@@ -11,6 +11,8 @@ import (
 // ConvertToBytes convierte un tamaño y una unidad a bytes
 func ConvertToBytes(size int, unit string) (int, error) {
 	switch unit {
+	case "B":
+		return size, nil // El tamaño ya está en bytes
 	case "K":
 		return size * 1024, nil // Convierte kilobytes a bytes
 	case "M":
@@ -20,42 +22,6 @@ func ConvertToBytes(size int, unit string) (int, error) {
 	}
 }
 
-// Lista con todo el abecedario
-var alphabet = []string{
-	"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L", "M",
-	"N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z",
-}
-
-// Mapa para almacenar la asignación de letras a los diferentes paths
-var pathToLetter = make(map[string]string)
-
-// Mapa para almacenar el contador de particiones por path
-var pathToPartitionCount = make(map[string]int)
-
-// Índice para la siguiente letra disponible en el abecedario
-var nextLetterIndex = 0
-
-// GetLetter obtiene la letra asignada a un path y el siguiente índice de partición
-func GetLetterAndPartitionCorrelative(path string) (string, int, error) {
-	// Asignar una letra al path si no tiene una asignada
-	if _, exists := pathToLetter[path]; !exists {
-		if nextLetterIndex < len(alphabet) {
-			pathToLetter[path] = alphabet[nextLetterIndex]
-			pathToPartitionCount[path] = 0 // Inicializar el contador de particiones
-			nextLetterIndex++
-		} else {
-			fmt.Println("Error: no hay más letras disponibles para asignar")
-			return "", 0, errors.New("no hay más letras disponibles para asignar")
-		}
-	}
-
-	// Incrementar y obtener el siguiente índice de partición para este path
-	pathToPartitionCount[path]++
-	nextIndex := pathToPartitionCount[path]
-
-	return pathToLetter[path], nextIndex, nil
-}
-
 // createParentDirs crea las carpetas padre si no existen
 func CreateParentDirs(path string) error {
 	dir := filepath.Dir(path)
@@ -76,28 +42,6 @@ func GetFileNames(path string) (string, string) {
 	return dotFileName, outputImage
 }
 
-// GetParentDirectories obtiene las carpetas padres y el directorio de destino
-func GetParentDirectories(path string) ([]string, string) {
-	// Normalizar el path
-	path = filepath.Clean(path)
-
-	// Dividir el path en sus componentes
-	components := strings.Split(path, string(filepath.Separator))
-
-	// Lista para almacenar las rutas de las carpetas padres
-	var parentDirs []string
-
-	// Construir las rutas de las carpetas padres, excluyendo la última carpeta
-	for i := 1; i < len(components)-1; i++ {
-		parentDirs = append(parentDirs, components[i])
-	}
-
-	// La última carpeta es la carpeta de destino
-	destDir := components[len(components)-1]
-
-	return parentDirs, destDir
-}
-
 // First devuelve el primer elemento de un slice
 func First[T any](slice []T) (T, error) {
 	if len(slice) == 0 {
@@ -128,17 +72,33 @@ func SplitStringIntoChunks(s string) []string {
 	return chunks
 }
 
-// GetPath obtiene el path asignado a una letra
-func GetPath(letter string) (string, error) {
-	// Buscar el path asociado a la letra
-	for path, assignedLetter := range pathToLetter {
-		if assignedLetter == letter {
-			return path, nil
-		}
+// WriteZeros rellena con ceros una región del archivo a partir de la posición indicada
+func WriteZeros(path string, start int64, size int64) error {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0644)
+	if err != nil {
+		return err
 	}
+	defer file.Close()
 
-	fmt.Println("Error: no se encontró el path asociado a la letra")
-	return "", errors.New("no se encontró el path asociado a la letra")
+	// Mover el puntero del archivo al inicio de la región
+	_, err = file.Seek(start, 0)
+	if err != nil {
+		return err
+	}
+
+	// Escribir en el archivo usando un buffer de 1 MB
+	buffer := make([]byte, 1024*1024)
+	for size > 0 {
+		writeSize := int64(len(buffer))
+		if size < writeSize {
+			writeSize = size // Ajusta el tamaño de escritura si es menor que el buffer
+		}
+		if _, err := file.Write(buffer[:writeSize]); err != nil {
+			return err
+		}
+		size -= writeSize
+	}
+	return nil
 }
 
 // FileExists verifica si un archivo existe en la ruta especificada